@@ -0,0 +1,67 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*Balance)(nil)
+var _ json.Unmarshaler = (*Balance)(nil)
+
+// Balance represents the funds of a trader in a currency.
+type Balance struct {
+	available decimal.Decimal
+	held      decimal.Decimal
+}
+
+// NewBalance creates a new balance.
+func NewBalance(available, held decimal.Decimal) *Balance {
+	return &Balance{available, held}
+}
+
+// Available returns the funds free to trade or withdraw.
+func (b *Balance) Available() decimal.Decimal {
+	return b.available
+}
+
+// Held returns the funds held by open orders.
+func (b *Balance) Held() decimal.Decimal {
+	return b.held
+}
+
+// Total returns the available and held funds.
+func (b *Balance) Total() decimal.Decimal {
+	return b.available.Add(b.held)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *Balance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Available decimal.Decimal `json:"available"`
+			Held      decimal.Decimal `json:"held"`
+		}{
+			b.available,
+			b.held,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Available decimal.Decimal `json:"available"`
+		Held      decimal.Decimal `json:"held"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("Balance.Unmarshal(%s): %w", data, err)
+	}
+
+	b.available = obj.Available
+	b.held = obj.Held
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*Interval)(nil)
+var _ json.Unmarshaler = (*Interval)(nil)
+var _ json.Marshaler = (*Candle)(nil)
+var _ json.Unmarshaler = (*Candle)(nil)
+
+// An Interval tells how long a candle lasts.
+type Interval time.Duration
+
+const (
+	// OneSecond candles
+	OneSecond = Interval(time.Second)
+
+	// OneMinute candles
+	OneMinute = Interval(time.Minute)
+
+	// FiveMinutes candles
+	FiveMinutes = Interval(5 * time.Minute)
+
+	// OneHour candles
+	OneHour = Interval(time.Hour)
+
+	// OneDay candles
+	OneDay = Interval(24 * time.Hour)
+)
+
+var intervals = map[Interval]string{OneSecond: "1s", OneMinute: "1m", FiveMinutes: "5m", OneHour: "1h", OneDay: "1d"}
+
+// String implements fmt.Stringer.
+func (i Interval) String() string {
+	if name, ok := intervals[i]; ok {
+		return name
+	}
+
+	return time.Duration(i).String()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + i.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	for interval, name := range intervals {
+		if string(data) == `"`+name+`"` {
+			*i = interval
+			return nil
+		}
+	}
+
+	if s, err := strconv.Unquote(string(data)); err == nil {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			*i = Interval(d)
+			return nil
+		}
+	}
+
+	return &json.UnsupportedValueError{
+		Value: reflect.New(reflect.TypeOf(data)),
+		Str:   string(data),
+	}
+}
+
+// Start returns the start of the interval holding the given time. Intervals are aligned on the Unix epoch, in UTC.
+func (i Interval) Start(t time.Time) time.Time {
+	d := int64(i)
+	n := t.UnixNano()
+
+	start := n - n%d
+	if n%d < 0 {
+		start -= d
+	}
+
+	return time.Unix(0, start).UTC()
+}
+
+// Candle represents the open, high, low, close and volume of the trades of an interval.
+type Candle struct {
+	interval Interval
+	start    time.Time
+	open     decimal.Decimal
+	high     decimal.Decimal
+	low      decimal.Decimal
+	close    decimal.Decimal
+	volume   decimal.Decimal
+	notional decimal.Decimal
+	count    int
+
+	first time.Time
+	last  time.Time
+}
+
+// NewCandle creates a new candle.
+func NewCandle(interval Interval, start time.Time, open, high, low, close, volume, notional decimal.Decimal, count int) *Candle {
+	return &Candle{interval, start, open, high, low, close, volume, notional, count, start, start}
+}
+
+// Interval returns the interval.
+func (c *Candle) Interval() Interval {
+	return c.interval
+}
+
+// Start returns the start time, inclusive.
+func (c *Candle) Start() time.Time {
+	return c.start
+}
+
+// End returns the end time, exclusive.
+func (c *Candle) End() time.Time {
+	return c.start.Add(time.Duration(c.interval))
+}
+
+// Open returns the price of the first trade.
+func (c *Candle) Open() decimal.Decimal {
+	return c.open
+}
+
+// High returns the highest price.
+func (c *Candle) High() decimal.Decimal {
+	return c.high
+}
+
+// Low returns the lowest price.
+func (c *Candle) Low() decimal.Decimal {
+	return c.low
+}
+
+// Close returns the price of the last trade.
+func (c *Candle) Close() decimal.Decimal {
+	return c.close
+}
+
+// Volume returns the traded amount.
+func (c *Candle) Volume() decimal.Decimal {
+	return c.volume
+}
+
+// Notional returns the traded amount times price.
+func (c *Candle) Notional() decimal.Decimal {
+	return c.notional
+}
+
+// VWAP returns the volume weighted average price.
+func (c *Candle) VWAP() decimal.Decimal {
+	if c.volume.IsZero() {
+		return decimal.Zero
+	}
+
+	return c.notional.Div(c.volume)
+}
+
+// Count returns the number of trades.
+func (c *Candle) Count() int {
+	return c.count
+}
+
+// add adds a trade of the interval. Trades older than the first or not older than the last one move the open or close.
+func (c *Candle) add(trade *Trade) {
+	if c.count == 0 {
+		c.open, c.high, c.low, c.close = trade.price, trade.price, trade.price, trade.price
+		c.first, c.last = trade.time, trade.time
+	}
+
+	if trade.time.Before(c.first) {
+		c.open, c.first = trade.price, trade.time
+	}
+
+	if !trade.time.Before(c.last) {
+		c.close, c.last = trade.price, trade.time
+	}
+
+	c.high = decimal.Max(c.high, trade.price)
+	c.low = decimal.Min(c.low, trade.price)
+	c.volume = c.volume.Add(trade.amount)
+	c.notional = c.notional.Add(trade.amount.Mul(trade.price))
+	c.count++
+}
+
+func (c *Candle) clone() *Candle {
+	clone := *c
+	return &clone
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Candle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Interval Interval        `json:"interval"`
+			Start    time.Time       `json:"start"`
+			Open     decimal.Decimal `json:"open"`
+			High     decimal.Decimal `json:"high"`
+			Low      decimal.Decimal `json:"low"`
+			Close    decimal.Decimal `json:"close"`
+			Volume   decimal.Decimal `json:"volume"`
+			Notional decimal.Decimal `json:"notional"`
+			VWAP     decimal.Decimal `json:"vwap"`
+			Count    int             `json:"count"`
+		}{
+			c.interval,
+			c.start,
+			c.open,
+			c.high,
+			c.low,
+			c.close,
+			c.volume,
+			c.notional,
+			c.VWAP(),
+			c.count,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Interval Interval        `json:"interval"`
+		Start    time.Time       `json:"start"`
+		Open     decimal.Decimal `json:"open"`
+		High     decimal.Decimal `json:"high"`
+		Low      decimal.Decimal `json:"low"`
+		Close    decimal.Decimal `json:"close"`
+		Volume   decimal.Decimal `json:"volume"`
+		Notional decimal.Decimal `json:"notional"`
+		Count    int             `json:"count"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("Candle.Unmarshal(%s): %w", data, err)
+	}
+
+	*c = *NewCandle(obj.Interval, obj.Start, obj.Open, obj.High, obj.Low, obj.Close, obj.Volume, obj.Notional, obj.Count)
+	return nil
+}
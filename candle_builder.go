@@ -0,0 +1,218 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ Listener = (*CandleBuilder)(nil)
+
+// CandleBuilder aggregates the trades of an order book into candles of many intervals, keeping the latest ones.
+// Subscribe it to the book, after a Backfill when there is a trade log. Intervals without trades have no candle.
+type CandleBuilder struct {
+	sync.RWMutex
+	intervals []Interval
+	limit     int
+	candles   map[Interval][]*Candle
+	tradeID   uint64
+}
+
+// NewCandleBuilder creates a new candle builder keeping up to limit candles per interval, all of them when zero.
+// Intervals must be positive and distinct.
+func NewCandleBuilder(limit int, intervals ...Interval) (*CandleBuilder, error) {
+	if limit < 0 {
+		return nil, ErrInvalidLimit
+	}
+
+	candles := make(map[Interval][]*Candle)
+	for _, interval := range intervals {
+		if _, ok := candles[interval]; ok || interval <= 0 {
+			return nil, ErrInvalidInterval
+		}
+
+		candles[interval] = make([]*Candle, 0)
+	}
+
+	return &CandleBuilder{intervals: intervals, limit: limit, candles: candles}, nil
+}
+
+// Intervals returns the intervals.
+func (b *CandleBuilder) Intervals() []Interval {
+	return b.intervals
+}
+
+// OnEvent implements Listener.
+func (b *CandleBuilder) OnEvent(event *Event) {
+	if event.eventType == TradeExecuted {
+		b.Add(event.trade)
+	}
+}
+
+// Add adds trades to the candles. Trades with an ID not greater than the last one added are skipped,
+// so a trade log and the book can overlap. Trades older than the kept candles are dropped.
+func (b *CandleBuilder) Add(trades ...*Trade) {
+	defer b.Unlock()
+	b.Lock()
+
+	for _, trade := range trades {
+		b.add(trade)
+	}
+}
+
+// Backfill adds the trades of a log, a stream of JSON trades such as one per line.
+func (b *CandleBuilder) Backfill(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+
+	for {
+		trade := &Trade{}
+		if err := decoder.Decode(trade); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("CandleBuilder.Backfill: %w", err)
+		}
+
+		b.Add(trade)
+	}
+}
+
+// Candles returns a copy of the candles of an interval, oldest first.
+func (b *CandleBuilder) Candles(interval Interval) []*Candle {
+	defer b.RUnlock()
+	b.RLock()
+
+	candles := make([]*Candle, 0, len(b.candles[interval]))
+	for _, candle := range b.candles[interval] {
+		candles = append(candles, candle.clone())
+	}
+
+	return candles
+}
+
+// Last returns a copy of the latest candle of an interval or nil when there is none.
+func (b *CandleBuilder) Last(interval Interval) *Candle {
+	defer b.RUnlock()
+	b.RLock()
+
+	candles := b.candles[interval]
+	if len(candles) == 0 {
+		return nil
+	}
+
+	return candles[len(candles)-1].clone()
+}
+
+func (b *CandleBuilder) add(trade *Trade) {
+	if trade.id > 0 {
+		if trade.id <= b.tradeID {
+			return
+		}
+
+		b.tradeID = trade.id
+	}
+
+	for _, interval := range b.intervals {
+		if candle := b.candle(interval, interval.Start(trade.time)); candle != nil {
+			candle.add(trade)
+		}
+	}
+}
+
+// candle returns the candle of an interval starting at the given time, creating it in order, or nil when it is older than the kept ones.
+func (b *CandleBuilder) candle(interval Interval, start time.Time) *Candle {
+	candles := b.candles[interval]
+
+	i := len(candles)
+	for i > 0 && candles[i-1].start.After(start) {
+		i--
+	}
+
+	if i > 0 && candles[i-1].start.Equal(start) {
+		return candles[i-1]
+	}
+
+	if b.limit > 0 && len(candles) == b.limit {
+		if i == 0 {
+			return nil
+		}
+
+		candles = candles[1:]
+		i--
+	}
+
+	candle := NewCandle(interval, start, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, 0)
+	candles = append(candles, nil)
+	copy(candles[i+1:], candles[i:])
+	candles[i] = candle
+
+	b.candles[interval] = candles
+	return candle
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *CandleBuilder) MarshalJSON() ([]byte, error) {
+	defer b.RUnlock()
+	b.RLock()
+
+	candles := make(map[string][]*Candle)
+	for interval, c := range b.candles {
+		candles[interval.String()] = c
+	}
+
+	return json.Marshal(
+		&struct {
+			Limit   int                  `json:"limit,omitempty"`
+			TradeID uint64               `json:"lastTradeId,omitempty"`
+			Candles map[string][]*Candle `json:"candles"`
+		}{
+			b.limit,
+			b.tradeID,
+			candles,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *CandleBuilder) UnmarshalJSON(data []byte) error {
+	defer b.Unlock()
+	b.Lock()
+
+	obj := struct {
+		Limit   int                  `json:"limit"`
+		TradeID uint64               `json:"lastTradeId"`
+		Candles map[string][]*Candle `json:"candles"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("CandleBuilder.Unmarshal(%s): %w", data, err)
+	}
+
+	b.limit = obj.Limit
+	b.tradeID = obj.TradeID
+	b.intervals = make([]Interval, 0, len(obj.Candles))
+	b.candles = make(map[Interval][]*Candle)
+
+	for name, candles := range obj.Candles {
+		var interval Interval
+		if err := interval.UnmarshalJSON([]byte(`"` + name + `"`)); err != nil {
+			return fmt.Errorf("CandleBuilder.Unmarshal(%s): %w", data, err)
+		}
+
+		b.intervals = append(b.intervals, interval)
+		b.candles[interval] = candles
+	}
+
+	sort.Slice(b.intervals, func(i, j int) bool {
+		return b.intervals[i] < b.intervals[j]
+	})
+
+	return nil
+}
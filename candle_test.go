@@ -0,0 +1,134 @@
+package orderbook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func candleTrades() []*orderbook.Trade {
+	at := func(d time.Duration) time.Time {
+		return clock().Add(d)
+	}
+
+	trade := func(id uint64, t time.Time, amount, price int64) *orderbook.Trade {
+		return orderbook.NewTrade(id, id, t, "t", "m", "1", "2", orderbook.Buy, decimal.NewFromInt(amount), decimal.NewFromInt(price))
+	}
+
+	return []*orderbook.Trade{
+		trade(1, at(0), 1, 100),
+		trade(2, at(10*time.Second), 2, 103),
+		trade(3, at(59*time.Second), 1, 98),
+		trade(4, at(time.Minute), 3, 101),
+		trade(5, at(6*time.Minute), 1, 104),
+	}
+}
+
+func TestCandleBuilder(t *testing.T) {
+	builder, err := orderbook.NewCandleBuilder(0, orderbook.OneMinute, orderbook.FiveMinutes, orderbook.OneDay)
+	assert.Nil(t, err)
+
+	builder.Add(candleTrades()...)
+
+	minutes := builder.Candles(orderbook.OneMinute)
+	assert.Len(t, minutes, 3)
+	assert.Equal(t, clock(), minutes[0].Start())
+	assert.Equal(t, clock().Add(time.Minute), minutes[0].End())
+	assert.Equal(t, "100", minutes[0].Open().String())
+	assert.Equal(t, "103", minutes[0].High().String())
+	assert.Equal(t, "98", minutes[0].Low().String())
+	assert.Equal(t, "98", minutes[0].Close().String())
+	assert.Equal(t, "4", minutes[0].Volume().String())
+	assert.Equal(t, "101", minutes[0].VWAP().String())
+	assert.Equal(t, 3, minutes[0].Count())
+
+	assert.Len(t, builder.Candles(orderbook.FiveMinutes), 2)
+	assert.Equal(t, 5, builder.Last(orderbook.OneDay).Count())
+	assert.Nil(t, builder.Last(orderbook.OneHour))
+
+	builder.Add(candleTrades()[4])
+	assert.Equal(t, 5, builder.Last(orderbook.OneDay).Count())
+
+	s, err := json.Marshal(builder)
+	assert.Nil(t, err)
+
+	restored, err := orderbook.NewCandleBuilder(0)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(s, restored))
+	assert.Equal(t, []orderbook.Interval{orderbook.OneMinute, orderbook.FiveMinutes, orderbook.OneDay}, restored.Intervals())
+
+	r, err := json.Marshal(restored)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(s), string(r))
+
+	cupaloy.SnapshotT(t, s)
+}
+
+func TestCandleBuilderValidations(t *testing.T) {
+	_, err := orderbook.NewCandleBuilder(0, orderbook.OneMinute, orderbook.Interval(0))
+	assert.Equal(t, orderbook.ErrInvalidInterval, err)
+
+	_, err = orderbook.NewCandleBuilder(0, orderbook.Interval(-time.Minute))
+	assert.Equal(t, orderbook.ErrInvalidInterval, err)
+
+	_, err = orderbook.NewCandleBuilder(0, orderbook.OneMinute, orderbook.OneMinute)
+	assert.Equal(t, orderbook.ErrInvalidInterval, err)
+
+	_, err = orderbook.NewCandleBuilder(-1, orderbook.OneMinute)
+	assert.Equal(t, orderbook.ErrInvalidLimit, err)
+}
+
+func TestCandleBuilderLimit(t *testing.T) {
+	builder, err := orderbook.NewCandleBuilder(2, orderbook.OneMinute)
+	assert.Nil(t, err)
+
+	trades := candleTrades()
+
+	builder.Add(trades[0], trades[3], trades[4])
+	assert.Len(t, builder.Candles(orderbook.OneMinute), 2)
+	assert.Equal(t, clock().Add(time.Minute), builder.Candles(orderbook.OneMinute)[0].Start())
+
+	late := orderbook.NewTrade(0, 0, clock(), "t", "m", "1", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1))
+	builder.Add(late)
+	assert.Equal(t, 1, builder.Candles(orderbook.OneMinute)[0].Count())
+}
+
+func TestCandleBuilderBackfill(t *testing.T) {
+	var log bytes.Buffer
+
+	encoder := json.NewEncoder(&log)
+	for _, trade := range candleTrades()[:3] {
+		assert.Nil(t, encoder.Encode(trade))
+	}
+
+	overlap := bytes.NewBuffer(log.Bytes())
+
+	builder, err := orderbook.NewCandleBuilder(0, orderbook.OneSecond, orderbook.OneHour)
+	assert.Nil(t, err)
+	assert.Nil(t, builder.Backfill(&log))
+	assert.Len(t, builder.Candles(orderbook.OneSecond), 3)
+
+	assert.Nil(t, builder.Backfill(overlap))
+	assert.Equal(t, 3, builder.Last(orderbook.OneHour).Count())
+
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(func() time.Time { return clock().Add(2 * time.Minute) }))
+	assert.Nil(t, json.Unmarshal([]byte(`{"symbol":"BTC/USD","bids":[],"asks":[],"lastTradeId":3,"version":3}`), book))
+	book.Subscribe(builder)
+
+	_, err = book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, builder.Last(orderbook.OneHour).Count())
+	assert.Equal(t, "100", builder.Last(orderbook.OneHour).Close().String())
+
+	assert.NotNil(t, builder.Backfill(bytes.NewBufferString("{")))
+}
@@ -0,0 +1,270 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*CommandType)(nil)
+var _ json.Unmarshaler = (*CommandType)(nil)
+var _ json.Marshaler = (*Command)(nil)
+var _ json.Unmarshaler = (*Command)(nil)
+
+// A CommandType tells which order book method a command was applied with.
+type CommandType int
+
+const (
+	// LimitCommand for ProcessLimitOrder
+	LimitCommand CommandType = 0
+
+	// MarketCommand for ProcessMarketOrder
+	MarketCommand CommandType = 1
+
+	// PostOnlyCommand for ProcessPostOnlyOrder
+	PostOnlyCommand CommandType = 2
+
+	// StopCommand for ProcessStopOrder
+	StopCommand CommandType = 3
+
+	// StopLimitCommand for ProcessStopLimitOrder
+	StopLimitCommand CommandType = 4
+
+	// CancelCommand for CancelOrder
+	CancelCommand CommandType = 5
+
+	// AmendCommand for AmendOrder
+	AmendCommand CommandType = 6
+
+	// ExpireCommand for ExpireOrders
+	ExpireCommand CommandType = 7
+
+	// StatusCommand for SetStatus and StartAuction
+	StatusCommand CommandType = 8
+
+	// UncrossCommand for Uncross
+	UncrossCommand CommandType = 9
+
+	// MarketFundsCommand for ProcessMarketOrderByFunds
+	MarketFundsCommand CommandType = 10
+
+	// InstrumentCommand for SetInstrument
+	InstrumentCommand CommandType = 11
+)
+
+var commandTypes = []string{"limit", "market", "postOnly", "stop", "stopLimit", "cancel", "amend", "expire", "status", "uncross", "marketFunds", "instrument"}
+
+// String implements fmt.Stringer.
+func (t CommandType) String() string {
+	if t < 0 || int(t) >= len(commandTypes) {
+		return "unknown"
+	}
+
+	return commandTypes[t]
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t CommandType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *CommandType) UnmarshalJSON(data []byte) error {
+	for i, name := range commandTypes {
+		if string(data) == `"`+name+`"` {
+			*t = CommandType(i)
+			return nil
+		}
+	}
+
+	return &json.UnsupportedValueError{
+		Value: reflect.New(reflect.TypeOf(data)),
+		Str:   string(data),
+	}
+}
+
+// Command represents an accepted call to the order book as recorded by the journal.
+type Command struct {
+	commandType CommandType
+	version     uint64
+	time        time.Time
+	orderID     string
+	traderID    string
+	side        Side
+	amount      decimal.Decimal
+	price       decimal.Decimal
+	stopPrice   decimal.Decimal
+	funds       decimal.Decimal
+	options     orderOptions
+	status      TradingStatus
+	instrument  *Instrument
+}
+
+// Type returns the command type.
+func (c *Command) Type() CommandType {
+	return c.commandType
+}
+
+// Version returns the book version produced by the command.
+func (c *Command) Version() uint64 {
+	return c.version
+}
+
+// Time returns the book clock time when the command was applied, or the expire time given to ExpireOrders.
+func (c *Command) Time() time.Time {
+	return c.time
+}
+
+// OrderID returns the order ID.
+func (c *Command) OrderID() string {
+	return c.orderID
+}
+
+// TraderID returns the trader ID.
+func (c *Command) TraderID() string {
+	return c.traderID
+}
+
+// Side returns the side.
+func (c *Command) Side() Side {
+	return c.side
+}
+
+// Amount returns the amount.
+func (c *Command) Amount() decimal.Decimal {
+	return c.amount
+}
+
+// Price returns the price.
+func (c *Command) Price() decimal.Decimal {
+	return c.price
+}
+
+// StopPrice returns the stop price.
+func (c *Command) StopPrice() decimal.Decimal {
+	return c.stopPrice
+}
+
+// Funds returns the funds of MarketFundsCommand.
+func (c *Command) Funds() decimal.Decimal {
+	return c.funds
+}
+
+// Status returns the trading status of StatusCommand.
+func (c *Command) Status() TradingStatus {
+	return c.status
+}
+
+// Instrument returns the trading rules of InstrumentCommand, nil when they were removed.
+func (c *Command) Instrument() *Instrument {
+	return c.instrument
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Command) MarshalJSON() ([]byte, error) {
+	var expireAt *time.Time
+	if !c.options.expireAt.IsZero() {
+		expireAt = &c.options.expireAt
+	}
+
+	var stopPrice, funds, displayAmount *decimal.Decimal
+	if !c.stopPrice.IsZero() {
+		stopPrice = &c.stopPrice
+	}
+
+	if !c.funds.IsZero() {
+		funds = &c.funds
+	}
+
+	if !c.options.displayAmount.IsZero() {
+		displayAmount = &c.options.displayAmount
+	}
+
+	return json.Marshal(
+		&struct {
+			Type                CommandType          `json:"type"`
+			Version             uint64               `json:"version"`
+			Time                time.Time            `json:"time"`
+			OrderID             string               `json:"orderId,omitempty"`
+			TraderID            string               `json:"traderId,omitempty"`
+			Side                Side                 `json:"side"`
+			Amount              decimal.Decimal      `json:"amount"`
+			Price               decimal.Decimal      `json:"price"`
+			StopPrice           *decimal.Decimal     `json:"stopPrice,omitempty"`
+			Funds               *decimal.Decimal     `json:"funds,omitempty"`
+			TimeInForce         TimeInForce          `json:"timeInForce"`
+			ExpireAt            *time.Time           `json:"expireAt,omitempty"`
+			DisplayAmount       *decimal.Decimal     `json:"displayAmount,omitempty"`
+			SelfTradePrevention *SelfTradePrevention `json:"selfTradePrevention,omitempty"`
+			Status              TradingStatus        `json:"status,omitempty"`
+			Instrument          *Instrument          `json:"instrument,omitempty"`
+		}{
+			c.commandType,
+			c.version,
+			c.time,
+			c.orderID,
+			c.traderID,
+			c.side,
+			c.amount,
+			c.price,
+			stopPrice,
+			funds,
+			c.options.timeInForce,
+			expireAt,
+			displayAmount,
+			c.options.selfTradePrevention,
+			c.status,
+			c.instrument,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Command) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Type                CommandType          `json:"type"`
+		Version             uint64               `json:"version"`
+		Time                time.Time            `json:"time"`
+		OrderID             string               `json:"orderId"`
+		TraderID            string               `json:"traderId"`
+		Side                Side                 `json:"side"`
+		Amount              decimal.Decimal      `json:"amount"`
+		Price               decimal.Decimal      `json:"price"`
+		StopPrice           decimal.Decimal      `json:"stopPrice"`
+		Funds               decimal.Decimal      `json:"funds"`
+		TimeInForce         TimeInForce          `json:"timeInForce"`
+		ExpireAt            time.Time            `json:"expireAt"`
+		DisplayAmount       decimal.Decimal      `json:"displayAmount"`
+		SelfTradePrevention *SelfTradePrevention `json:"selfTradePrevention"`
+		Status              TradingStatus        `json:"status"`
+		Instrument          *Instrument          `json:"instrument"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("Command.Unmarshal(%s): %w", data, err)
+	}
+
+	c.commandType = obj.Type
+	c.version = obj.Version
+	c.time = obj.Time
+	c.orderID = obj.OrderID
+	c.traderID = obj.TraderID
+	c.side = obj.Side
+	c.amount = obj.Amount
+	c.price = obj.Price
+	c.stopPrice = obj.StopPrice
+	c.funds = obj.Funds
+	c.status = obj.Status
+	c.instrument = obj.Instrument
+	c.options = orderOptions{
+		timeInForce:         obj.TimeInForce,
+		expireAt:            obj.ExpireAt,
+		displayAmount:       obj.DisplayAmount,
+		selfTradePrevention: obj.SelfTradePrevention,
+	}
+
+	return nil
+}
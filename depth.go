@@ -10,13 +10,14 @@ var _ json.Unmarshaler = (*Depth)(nil)
 
 // Depth represents a order book depth.
 type Depth struct {
-	bids []*PriceLevel
-	asks []*PriceLevel
+	bids    []*PriceLevel
+	asks    []*PriceLevel
+	version uint64
 }
 
 // NewDepth creates a new depth.
 func NewDepth(bids, asks []*PriceLevel) *Depth {
-	return &Depth{bids, asks}
+	return &Depth{bids, asks, 0}
 }
 
 // Bids returns a range of price leves.
@@ -29,15 +30,22 @@ func (d *Depth) Asks() []*PriceLevel {
 	return d.asks
 }
 
+// Version returns the book version the depth was taken at.
+func (d *Depth) Version() uint64 {
+	return d.version
+}
+
 // MarshalJSON implements json.MarshalJSON.
 func (d *Depth) MarshalJSON() ([]byte, error) {
 	return json.Marshal(
 		&struct {
-			Bids []*PriceLevel `json:"bids"`
-			Asks []*PriceLevel `json:"asks"`
+			Bids    []*PriceLevel `json:"bids"`
+			Asks    []*PriceLevel `json:"asks"`
+			Version uint64        `json:"version,omitempty"`
 		}{
 			d.bids,
 			d.asks,
+			d.version,
 		},
 	)
 }
@@ -45,8 +53,9 @@ func (d *Depth) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements json.Unmarshaler.
 func (d *Depth) UnmarshalJSON(data []byte) error {
 	obj := struct {
-		Bids []*PriceLevel `json:"bids"`
-		Asks []*PriceLevel `json:"asks"`
+		Bids    []*PriceLevel `json:"bids"`
+		Asks    []*PriceLevel `json:"asks"`
+		Version uint64        `json:"version"`
 	}{}
 
 	if err := json.Unmarshal(data, &obj); err != nil {
@@ -55,6 +64,7 @@ func (d *Depth) UnmarshalJSON(data []byte) error {
 
 	d.bids = obj.Bids
 	d.asks = obj.Asks
+	d.version = obj.Version
 
 	return nil
 }
@@ -0,0 +1,95 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*DepthUpdate)(nil)
+var _ json.Unmarshaler = (*DepthUpdate)(nil)
+
+// DepthUpdate represents the new aggregated amount of a price level. A zero amount means the level was removed.
+type DepthUpdate struct {
+	side    Side
+	price   decimal.Decimal
+	amount  decimal.Decimal
+	version uint64
+}
+
+// NewDepthUpdate creates a new depth update.
+func NewDepthUpdate(side Side, price, amount decimal.Decimal, version uint64) *DepthUpdate {
+	return &DepthUpdate{side, price, amount, version}
+}
+
+// Side returns the side.
+func (u *DepthUpdate) Side() Side {
+	return u.side
+}
+
+// Price returns the price.
+func (u *DepthUpdate) Price() decimal.Decimal {
+	return u.price
+}
+
+// Amount returns the new amount of the level.
+func (u *DepthUpdate) Amount() decimal.Decimal {
+	return u.amount
+}
+
+// Version returns the book version produced by the change.
+func (u *DepthUpdate) Version() uint64 {
+	return u.version
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u *DepthUpdate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Side    Side            `json:"side"`
+			Price   decimal.Decimal `json:"price"`
+			Amount  decimal.Decimal `json:"amount"`
+			Version uint64          `json:"version"`
+		}{
+			u.side,
+			u.price,
+			u.amount,
+			u.version,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *DepthUpdate) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Side    Side            `json:"side"`
+		Price   decimal.Decimal `json:"price"`
+		Amount  decimal.Decimal `json:"amount"`
+		Version uint64          `json:"version"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("DepthUpdate.Unmarshal(%s): %w", data, err)
+	}
+
+	u.side = obj.Side
+	u.price = obj.Price
+	u.amount = obj.Amount
+	u.version = obj.Version
+
+	return nil
+}
+
+// DepthListener receives the depth updates of an order book.
+type DepthListener interface {
+	OnDepthUpdate(update *DepthUpdate)
+}
+
+// DepthListenerFunc calls the function with each depth update.
+type DepthListenerFunc func(update *DepthUpdate)
+
+// OnDepthUpdate implements DepthListener.
+func (f DepthListenerFunc) OnDepthUpdate(update *DepthUpdate) {
+	f(update)
+}
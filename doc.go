@@ -1,4 +1,9 @@
 /*
 Package orderbook is a Limit Order Book for high-frequency trading.
+
+Listeners and risk checks are called synchronously while the book is locked, so they must not call the book back.
+Risk checks read it through the BookView they are given instead.
+
+Feed updates are tagged with the book version: a snapshot is kept up to date by applying the updates with a greater version.
 */
 package orderbook
@@ -0,0 +1,331 @@
+package orderbook
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// depthBatch is the most commands applied before the depth snapshot is published again while the queue stays busy.
+const depthBatch = 64
+
+// Engine runs an order book on a dedicated goroutine. Calls are queued on a bounded channel and applied one
+// at a time in the order they were submitted, replying through futures. The book lock is only ever taken by
+// the engine goroutine, and readers get the depth from a snapshot published after each burst of commands,
+// or every depthBatch commands under load. The engine keeps its own copy of the levels up to date from the
+// depth updates of the book, so publishing does not walk the book.
+type Engine struct {
+	book     *OrderBook
+	commands chan func()
+	depth    atomic.Pointer[Depth]
+	closed   bool
+	mutex    sync.RWMutex
+	done     chan struct{}
+
+	bids  []*PriceLevel
+	asks  []*PriceLevel
+	dirty bool
+	stale bool
+}
+
+// NewEngine starts an engine for the order book with a queue of the given capacity.
+// The book must not be called directly while the engine runs.
+func NewEngine(book *OrderBook, capacity int) *Engine {
+	e := &Engine{
+		book:     book,
+		commands: make(chan func(), capacity),
+		done:     make(chan struct{}),
+	}
+
+	book.SubscribeDepth(DepthListenerFunc(e.onDepthUpdate))
+
+	depth := book.Depth()
+	e.bids, e.asks = depth.bids, depth.asks
+	e.depth.Store(&Depth{levelsCopy(e.bids), levelsCopy(e.asks), depth.version})
+
+	go e.run()
+	return e
+}
+
+// Depth returns the depth as of the last burst, or batch, of applied commands. It never waits on the engine.
+func (e *Engine) Depth() *Depth {
+	return e.depth.Load()
+}
+
+// ProcessLimitOrder queues a limit order. The future holds the trades.
+func (e *Engine) ProcessLimitOrder(orderID, traderID string, side Side, amount, price decimal.Decimal, opts ...OrderOption) *Future {
+	return e.submit(func(f *Future) {
+		f.trades, f.err = e.book.ProcessLimitOrder(orderID, traderID, side, amount, price, opts...)
+	})
+}
+
+// ProcessMarketOrder queues a market order. The future holds the execution and its trades.
+func (e *Engine) ProcessMarketOrder(orderID, traderID string, side Side, amount decimal.Decimal, opts ...OrderOption) *Future {
+	return e.submit(func(f *Future) {
+		f.execution, f.err = e.book.ProcessMarketOrder(orderID, traderID, side, amount, opts...)
+	})
+}
+
+// ProcessMarketOrderByFunds queues a market order by funds. The future holds the execution and its trades.
+func (e *Engine) ProcessMarketOrderByFunds(orderID, traderID string, side Side, funds decimal.Decimal, opts ...OrderOption) *Future {
+	return e.submit(func(f *Future) {
+		f.execution, f.err = e.book.ProcessMarketOrderByFunds(orderID, traderID, side, funds, opts...)
+	})
+}
+
+// ProcessPostOnlyOrder queues a post only order.
+func (e *Engine) ProcessPostOnlyOrder(orderID, traderID string, side Side, amount, price decimal.Decimal) *Future {
+	return e.submit(func(f *Future) {
+		f.trades, f.err = e.book.ProcessPostOnlyOrder(orderID, traderID, side, amount, price)
+	})
+}
+
+// ProcessStopOrder queues a stop order. The future holds the trades when it triggers at once.
+func (e *Engine) ProcessStopOrder(orderID, traderID string, side Side, amount, stopPrice decimal.Decimal) *Future {
+	return e.submit(func(f *Future) {
+		f.trades, f.err = e.book.ProcessStopOrder(orderID, traderID, side, amount, stopPrice)
+	})
+}
+
+// ProcessStopLimitOrder queues a stop limit order. The future holds the trades when it triggers at once.
+func (e *Engine) ProcessStopLimitOrder(orderID, traderID string, side Side, amount, price, stopPrice decimal.Decimal) *Future {
+	return e.submit(func(f *Future) {
+		f.trades, f.err = e.book.ProcessStopLimitOrder(orderID, traderID, side, amount, price, stopPrice)
+	})
+}
+
+// AmendOrder queues an amendment. The future holds the trades.
+func (e *Engine) AmendOrder(orderID string, amount, price decimal.Decimal) *Future {
+	return e.submit(func(f *Future) {
+		f.trades, f.err = e.book.AmendOrder(orderID, amount, price)
+	})
+}
+
+// CancelOrder queues a cancel. The future holds the cancelled order or why it was not cancelled.
+func (e *Engine) CancelOrder(orderID string) *Future {
+	return e.submit(func(f *Future) {
+		f.order, f.err = e.book.CancelOrderErr(orderID)
+	})
+}
+
+// ExpireOrders queues the expiry of the orders expired at the given time. The future holds the expired orders.
+func (e *Engine) ExpireOrders(now time.Time) *Future {
+	return e.submit(func(f *Future) {
+		f.orders = e.book.ExpireOrders(now)
+	})
+}
+
+// SetStatus queues a trading status change.
+func (e *Engine) SetStatus(status TradingStatus) *Future {
+	return e.submit(func(f *Future) {
+		f.err = e.book.SetStatus(status)
+	})
+}
+
+// Uncross queues the uncrossing of an auction. The future holds the trades.
+func (e *Engine) Uncross() *Future {
+	return e.submit(func(f *Future) {
+		f.trades, f.err = e.book.Uncross()
+	})
+}
+
+// Do queues a function called with the book on the engine goroutine, for queries the engine does not cover.
+// The function must not keep the book. As it may replace the levels without depth updates, the depth is
+// rebuilt from the book after it.
+func (e *Engine) Do(fn func(ob *OrderBook) error) *Future {
+	return e.submit(func(f *Future) {
+		f.err = fn(e.book)
+		e.stale = true
+	})
+}
+
+// Close stops accepting calls, waits for the queued ones to be applied and stops the engine.
+func (e *Engine) Close() {
+	e.mutex.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.commands)
+	}
+	e.mutex.Unlock()
+
+	<-e.done
+}
+
+func (e *Engine) submit(apply func(f *Future)) *Future {
+	f := newFuture()
+
+	defer e.mutex.RUnlock()
+	e.mutex.RLock()
+
+	if e.closed {
+		f.resolve(ErrEngineClosed)
+		return f
+	}
+
+	e.commands <- func() {
+		apply(f)
+		f.resolve(f.err)
+	}
+
+	return f
+}
+
+func (e *Engine) run() {
+	defer close(e.done)
+	version := e.book.version
+	applied := 0
+
+	for command := range e.commands {
+		command()
+		applied++
+
+		if (len(e.commands) == 0 || applied >= depthBatch) && (e.book.version != version || e.stale) {
+			version = e.book.version
+			applied = 0
+			e.publish()
+		}
+	}
+}
+
+// publish stores a depth snapshot of the levels, copying them only when they changed since the last one.
+func (e *Engine) publish() {
+	if e.stale {
+		depth := e.book.Depth()
+		e.bids, e.asks = depth.bids, depth.asks
+		e.stale, e.dirty = false, true
+	}
+
+	last := e.depth.Load()
+	if !e.dirty {
+		e.depth.Store(&Depth{last.bids, last.asks, e.book.version})
+		return
+	}
+
+	e.dirty = false
+	e.depth.Store(&Depth{levelsCopy(e.bids), levelsCopy(e.asks), e.book.version})
+}
+
+// onDepthUpdate applies a depth update of the book to the levels, kept best first.
+func (e *Engine) onDepthUpdate(update *DepthUpdate) {
+	levels, worse := &e.asks, update.price.LessThanOrEqual
+	if update.side == Buy {
+		levels, worse = &e.bids, update.price.GreaterThanOrEqual
+	}
+
+	i := sort.Search(len(*levels), func(i int) bool { return worse((*levels)[i].price) })
+	found := i < len(*levels) && (*levels)[i].price.Equal(update.price)
+
+	switch {
+	case update.amount.IsZero():
+		if found {
+			*levels = append((*levels)[:i], (*levels)[i+1:]...)
+		}
+	case found:
+		(*levels)[i] = NewPriceLevel(update.price, update.amount)
+	default:
+		*levels = append(*levels, nil)
+		copy((*levels)[i+1:], (*levels)[i:])
+		(*levels)[i] = NewPriceLevel(update.price, update.amount)
+	}
+
+	e.dirty = true
+}
+
+func levelsCopy(levels []*PriceLevel) []*PriceLevel {
+	return append(make([]*PriceLevel, 0, len(levels)), levels...)
+}
+
+// Future represents the pending reply of an engine call.
+type Future struct {
+	mutex     sync.Mutex
+	done      chan struct{}
+	callbacks []func(*Future)
+	trades    []*Trade
+	execution *Execution
+	order     *Order
+	orders    []*Order
+	err       error
+}
+
+func newFuture() *Future {
+	return &Future{done: make(chan struct{})}
+}
+
+// Done returns a channel closed once the call was applied.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits for the call to be applied and returns its error.
+func (f *Future) Wait() error {
+	<-f.done
+	return f.err
+}
+
+// Then registers a callback called once the call was applied. It is called on the engine goroutine, so it must not
+// block nor wait on another call, or right away when the call was already applied.
+func (f *Future) Then(callback func(f *Future)) *Future {
+	f.mutex.Lock()
+
+	select {
+	case <-f.done:
+		f.mutex.Unlock()
+		callback(f)
+	default:
+		f.callbacks = append(f.callbacks, callback)
+		f.mutex.Unlock()
+	}
+
+	return f
+}
+
+// Trades returns the trades. It waits for the call to be applied.
+func (f *Future) Trades() []*Trade {
+	<-f.done
+
+	if f.execution != nil {
+		return f.execution.Trades()
+	}
+
+	return f.trades
+}
+
+// Execution returns the execution of a market order. It waits for the call to be applied.
+func (f *Future) Execution() *Execution {
+	<-f.done
+	return f.execution
+}
+
+// Order returns the cancelled order. It waits for the call to be applied.
+func (f *Future) Order() *Order {
+	<-f.done
+	return f.order
+}
+
+// Orders returns the expired orders. It waits for the call to be applied.
+func (f *Future) Orders() []*Order {
+	<-f.done
+	return f.orders
+}
+
+// Err returns the error. It waits for the call to be applied.
+func (f *Future) Err() error {
+	<-f.done
+	return f.err
+}
+
+func (f *Future) resolve(err error) {
+	f.mutex.Lock()
+	f.err = err
+	close(f.done)
+	callbacks := f.callbacks
+	f.callbacks = nil
+	f.mutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(f)
+	}
+}
@@ -0,0 +1,165 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine(t *testing.T) {
+	engine := orderbook.NewEngine(orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock)), 16)
+	assert.Empty(t, engine.Depth().Asks())
+
+	assert.Nil(t, engine.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100)).Wait())
+	assert.Nil(t, engine.ProcessPostOnlyOrder("2", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90)).Wait())
+
+	market := engine.ProcessMarketOrder("3", "2", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, market.Err())
+	assert.Len(t, market.Trades(), 1)
+	assert.Equal(t, "1", market.Execution().Amount().String())
+
+	called := make(chan *orderbook.Future, 1)
+	engine.CancelOrder("1").Then(func(f *orderbook.Future) { called <- f })
+
+	cancel := <-called
+	assert.Nil(t, cancel.Err())
+	assert.Equal(t, "1", cancel.Order().ID())
+
+	assert.Equal(t, orderbook.ErrOrderNotFound, engine.CancelOrder("1").Err())
+
+	assert.Nil(t, engine.SetStatus(orderbook.Halted).Wait())
+	assert.Equal(t, orderbook.ErrMarketHalted, engine.CancelOrder("2").Err())
+	assert.Nil(t, engine.SetStatus(orderbook.Open).Wait())
+	assert.Equal(t, orderbook.ErrInvalidAmount, engine.AmendOrder("2", decimal.Zero, decimal.NewFromInt(90)).Err())
+
+	expireAt := clock().Add(time.Minute)
+	assert.Nil(t, engine.ProcessLimitOrder("5", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(110), orderbook.WithTimeInForce(orderbook.GTD), orderbook.WithExpireTime(expireAt)).Wait())
+
+	expired := engine.ExpireOrders(expireAt).Orders()
+	assert.Len(t, expired, 1)
+	assert.Equal(t, "5", expired[0].ID())
+
+	var version uint64
+	assert.Nil(t, engine.Do(func(ob *orderbook.OrderBook) error {
+		version = ob.Version()
+		return nil
+	}).Wait())
+
+	depth := engine.Depth()
+	assert.Equal(t, version, depth.Version())
+	assert.Empty(t, depth.Asks())
+	assert.Len(t, depth.Bids(), 1)
+
+	engine.Close()
+	engine.Close()
+
+	closed := engine.ProcessLimitOrder("4", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Equal(t, orderbook.ErrEngineClosed, closed.Err())
+
+	called = make(chan *orderbook.Future, 1)
+	closed.Then(func(f *orderbook.Future) { called <- f })
+	assert.Equal(t, closed, <-called)
+}
+
+func TestEngineDepthUpdates(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+	engine := orderbook.NewEngine(orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock)), 16)
+
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprint(i)
+		side, price := orderbook.Sell, int64(100+i%5)
+		if i%2 == 0 {
+			side, price = orderbook.Buy, int64(90-i%5)
+		}
+
+		_, err := book.ProcessLimitOrder(id, id, side, decimal.NewFromInt(2), decimal.NewFromInt(price))
+		assert.Nil(t, err)
+		assert.Nil(t, engine.ProcessLimitOrder(id, id, side, decimal.NewFromInt(2), decimal.NewFromInt(price)).Wait())
+	}
+
+	_, err := book.ProcessMarketOrder("market", "market", orderbook.Buy, decimal.NewFromInt(3))
+	assert.Nil(t, err)
+	assert.Nil(t, engine.ProcessMarketOrder("market", "market", orderbook.Buy, decimal.NewFromInt(3)).Wait())
+
+	assert.NotNil(t, book.CancelOrder("4"))
+	assert.Nil(t, engine.CancelOrder("4").Wait())
+	engine.Close()
+
+	expected, err := json.Marshal(book.Depth())
+	assert.Nil(t, err)
+
+	actual, err := json.Marshal(engine.Depth())
+	assert.Nil(t, err)
+
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestEngineConcurrency(t *testing.T) {
+	engine := orderbook.NewEngine(orderbook.NewOrderBook("BTC/USD"), 4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				side := orderbook.Buy
+				if j%2 == 0 {
+					side = orderbook.Sell
+				}
+
+				id := fmt.Sprintf("%d-%d", i, j)
+				assert.Nil(t, engine.ProcessLimitOrder(id, id, side, decimal.NewFromInt(1), decimal.NewFromInt(100)).Err())
+				engine.Depth()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	engine.Close()
+
+	depth := engine.Depth()
+	assert.Empty(t, depth.Asks())
+	assert.Empty(t, depth.Bids())
+}
+
+func TestEngineDepthUnderLoad(t *testing.T) {
+	engine := orderbook.NewEngine(orderbook.NewOrderBook("BTC/USD"), 256)
+	defer engine.Close()
+
+	start, release := make(chan struct{}), make(chan struct{})
+	busy := make(chan struct{})
+
+	engine.Do(func(ob *orderbook.OrderBook) error {
+		<-start
+		return nil
+	})
+
+	for i := 0; i < 100; i++ {
+		engine.ProcessLimitOrder(fmt.Sprint(i), "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(int64(100+i)))
+	}
+
+	engine.Do(func(ob *orderbook.OrderBook) error {
+		close(busy)
+		<-release
+		return nil
+	})
+
+	for i := 100; i < 110; i++ {
+		engine.ProcessLimitOrder(fmt.Sprint(i), "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(int64(100+i)))
+	}
+
+	close(start)
+	<-busy
+
+	assert.NotEmpty(t, engine.Depth().Asks())
+	close(release)
+}
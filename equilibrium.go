@@ -0,0 +1,72 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*Equilibrium)(nil)
+var _ json.Unmarshaler = (*Equilibrium)(nil)
+
+// Equilibrium represents the single price an auction would uncross at.
+type Equilibrium struct {
+	price     decimal.Decimal
+	amount    decimal.Decimal
+	imbalance decimal.Decimal
+}
+
+// NewEquilibrium creates a new equilibrium.
+func NewEquilibrium(price, amount, imbalance decimal.Decimal) *Equilibrium {
+	return &Equilibrium{price, amount, imbalance}
+}
+
+// Price returns the clearing price.
+func (e *Equilibrium) Price() decimal.Decimal {
+	return e.price
+}
+
+// Amount returns the amount executable at the clearing price.
+func (e *Equilibrium) Amount() decimal.Decimal {
+	return e.amount
+}
+
+// Imbalance returns the amount left unmatched at the clearing price. Positive when bids are in surplus, negative when asks are.
+func (e *Equilibrium) Imbalance() decimal.Decimal {
+	return e.imbalance
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *Equilibrium) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Price     decimal.Decimal `json:"price"`
+			Amount    decimal.Decimal `json:"amount"`
+			Imbalance decimal.Decimal `json:"imbalance"`
+		}{
+			e.price,
+			e.amount,
+			e.imbalance,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Equilibrium) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Price     decimal.Decimal `json:"price"`
+		Amount    decimal.Decimal `json:"amount"`
+		Imbalance decimal.Decimal `json:"imbalance"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("Equilibrium.Unmarshal(%s): %w", data, err)
+	}
+
+	e.price = obj.Price
+	e.amount = obj.Amount
+	e.imbalance = obj.Imbalance
+
+	return nil
+}
@@ -4,10 +4,45 @@ import "errors"
 
 // Orderbook erros
 var (
-	ErrInvalidOrderID     = errors.New("Invalid order id")
-	ErrInvalidTraderID    = errors.New("Invalid trader id")
-	ErrInvalidAmount      = errors.New("Invalid amount")
-	ErrInvalidPrice       = errors.New("Invalid price")
-	ErrInvalidSide        = errors.New("Invalid side")
-	ErrOrderAlreadyExists = errors.New("Order already exists")
+	ErrInvalidOrderID             = errors.New("Invalid order id")
+	ErrInvalidTraderID            = errors.New("Invalid trader id")
+	ErrInvalidAmount              = errors.New("Invalid amount")
+	ErrInvalidPrice               = errors.New("Invalid price")
+	ErrInvalidFunds               = errors.New("Invalid funds")
+	ErrInvalidSide                = errors.New("Invalid side")
+	ErrOrderNotFound              = errors.New("Order not found")
+	ErrOrderAlreadyExists         = errors.New("Order already exists")
+	ErrInvalidStopPrice           = errors.New("Invalid stop price")
+	ErrInvalidTimeInForce         = errors.New("Invalid time in force")
+	ErrInvalidExpireTime          = errors.New("Invalid expire time")
+	ErrInvalidDisplayAmount       = errors.New("Invalid display amount")
+	ErrInvalidSelfTradePrevention = errors.New("Invalid self trade prevention")
+	ErrInvalidTickSize            = errors.New("Price is not a multiple of the tick size")
+	ErrInvalidLotSize             = errors.New("Amount is not a multiple of the lot size")
+	ErrAmountTooSmall             = errors.New("Amount too small")
+	ErrAmountTooLarge             = errors.New("Amount too large")
+	ErrNotionalTooSmall           = errors.New("Notional too small")
+	ErrPriceOutOfBounds           = errors.New("Price out of bounds")
+	ErrTooManyPriceLevels         = errors.New("Too many price levels")
+	ErrAuctionInProgress          = errors.New("Auction in progress")
+	ErrNoAuction                  = errors.New("No auction in progress")
+	ErrMarketHalted               = errors.New("Market halted")
+	ErrMarketClosed               = errors.New("Market closed")
+	ErrCancelOnly                 = errors.New("Market is cancel only")
+	ErrPostOnlyOnly               = errors.New("Market is post only")
+	ErrPostOnlyWouldCross         = errors.New("Post only order would cross")
+	ErrInvalidStatusTransition    = errors.New("Invalid trading status transition")
+	ErrCoolingDown                = errors.New("Circuit breaker cooling down")
+	ErrMaxAmountExceeded          = errors.New("Max order amount exceeded")
+	ErrMaxNotionalExceeded        = errors.New("Max order notional exceeded")
+	ErrPriceTooFar                = errors.New("Price too far from the best price")
+	ErrTooManyOpenOrders          = errors.New("Too many open orders")
+	ErrInsufficientFunds          = errors.New("Insufficient funds")
+	ErrMakerInsufficientFunds     = errors.New("Maker has insufficient funds")
+	ErrInvalidSymbol              = errors.New("Invalid symbol")
+	ErrMarketNotFound             = errors.New("Market not found")
+	ErrMarketAlreadyExists        = errors.New("Market already exists")
+	ErrEngineClosed               = errors.New("Engine closed")
+	ErrInvalidInterval            = errors.New("Invalid interval")
+	ErrInvalidLimit               = errors.New("Invalid limit")
 )
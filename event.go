@@ -0,0 +1,147 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var _ json.Marshaler = (*EventType)(nil)
+var _ json.Unmarshaler = (*EventType)(nil)
+var _ json.Marshaler = (*Event)(nil)
+
+// An EventType tells what happened to an order.
+type EventType int
+
+const (
+	// OrderAccepted when an order passes the validations
+	OrderAccepted EventType = 0
+
+	// OrderRested when an order, or its unfilled amount, is added to the book
+	OrderRested EventType = 1
+
+	// OrderPartiallyFilled when an order is matched and still has an amount left
+	OrderPartiallyFilled EventType = 2
+
+	// OrderFilled when an order is matched and has nothing left
+	OrderFilled EventType = 3
+
+	// OrderCancelled when an order, or its unfilled amount, is cancelled
+	OrderCancelled EventType = 4
+
+	// OrderRejected when an order fails the validations
+	OrderRejected EventType = 5
+
+	// TradeExecuted when a taker and a maker are matched
+	TradeExecuted EventType = 6
+
+	// OrderAmended when a resting order is reduced in place, keeping its queue position
+	OrderAmended EventType = 7
+)
+
+var eventTypes = []string{"orderAccepted", "orderRested", "orderPartiallyFilled", "orderFilled", "orderCancelled", "orderRejected", "tradeExecuted", "orderAmended"}
+
+// String implements fmt.Stringer.
+func (t EventType) String() string {
+	if t < 0 || int(t) >= len(eventTypes) {
+		return "unknown"
+	}
+
+	return eventTypes[t]
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t EventType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *EventType) UnmarshalJSON(data []byte) error {
+	for i, name := range eventTypes {
+		if string(data) == `"`+name+`"` {
+			*t = EventType(i)
+			return nil
+		}
+	}
+
+	return &json.UnsupportedValueError{
+		Value: reflect.New(reflect.TypeOf(data)),
+		Str:   string(data),
+	}
+}
+
+// Event represents something that happened to an order at a given book version.
+type Event struct {
+	eventType EventType
+	version   uint64
+	order     *Order
+	trade     *Trade
+	err       error
+}
+
+// NewEvent creates a new event.
+func NewEvent(eventType EventType, version uint64, order *Order, trade *Trade, err error) *Event {
+	return &Event{eventType, version, order, trade, err}
+}
+
+// Type returns the event type.
+func (e *Event) Type() EventType {
+	return e.eventType
+}
+
+// Version returns the book version produced by the change.
+func (e *Event) Version() uint64 {
+	return e.version
+}
+
+// Order returns a copy of the order as it is after the event, with the amount left open.
+// It is nil for TradeExecuted.
+func (e *Event) Order() *Order {
+	return e.order
+}
+
+// Trade returns the trade of TradeExecuted, OrderPartiallyFilled and OrderFilled of a maker.
+func (e *Event) Trade() *Trade {
+	return e.trade
+}
+
+// Err returns the reason of OrderRejected.
+func (e *Event) Err() error {
+	return e.err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *Event) MarshalJSON() ([]byte, error) {
+	var err string
+	if e.err != nil {
+		err = e.err.Error()
+	}
+
+	return json.Marshal(
+		&struct {
+			Type    EventType `json:"type"`
+			Version uint64    `json:"version"`
+			Order   *Order    `json:"order,omitempty"`
+			Trade   *Trade    `json:"trade,omitempty"`
+			Err     string    `json:"error,omitempty"`
+		}{
+			e.eventType,
+			e.version,
+			e.order,
+			e.trade,
+			err,
+		},
+	)
+}
+
+// Listener receives the order book events.
+type Listener interface {
+	OnEvent(event *Event)
+}
+
+// ListenerFunc calls the function with each event.
+type ListenerFunc func(event *Event)
+
+// OnEvent implements Listener.
+func (f ListenerFunc) OnEvent(event *Event) {
+	f(event)
+}
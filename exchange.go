@@ -0,0 +1,209 @@
+package orderbook
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// Exchange represents the order books of many markets keyed by symbol.
+// Every call is routed to the book of the symbol, which does its own locking.
+type Exchange struct {
+	sync.RWMutex
+	books map[string]*OrderBook
+	opts  []Option
+}
+
+// NewExchange creates a new exchange. The options are applied to every market before the options of the market.
+func NewExchange(opts ...Option) *Exchange {
+	return &Exchange{books: make(map[string]*OrderBook), opts: opts}
+}
+
+// AddMarket creates the order book of a symbol.
+func (e *Exchange) AddMarket(symbol string, opts ...Option) (*OrderBook, error) {
+	defer e.Unlock()
+	e.Lock()
+
+	if strings.TrimSpace(symbol) == "" {
+		return nil, ErrInvalidSymbol
+	}
+
+	if _, ok := e.books[symbol]; ok {
+		return nil, ErrMarketAlreadyExists
+	}
+
+	ob := NewOrderBook(symbol, append(append([]Option{}, e.opts...), opts...)...)
+	e.books[symbol] = ob
+
+	return ob, nil
+}
+
+// RemoveMarket removes the order book of a symbol and returns it.
+func (e *Exchange) RemoveMarket(symbol string) (*OrderBook, error) {
+	defer e.Unlock()
+	e.Lock()
+
+	ob, ok := e.books[symbol]
+	if !ok {
+		return nil, ErrMarketNotFound
+	}
+
+	delete(e.books, symbol)
+	return ob, nil
+}
+
+// Book returns the order book of a symbol or nil when it is not found.
+func (e *Exchange) Book(symbol string) *OrderBook {
+	defer e.RUnlock()
+	e.RLock()
+
+	return e.books[symbol]
+}
+
+// Symbols returns the symbols of the markets in ascending order.
+func (e *Exchange) Symbols() []string {
+	defer e.RUnlock()
+	e.RLock()
+
+	symbols := make([]string, 0, len(e.books))
+	for symbol := range e.books {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Strings(symbols)
+	return symbols
+}
+
+// SetInstrument sets the trading rules of a market.
+func (e *Exchange) SetInstrument(symbol string, instrument *Instrument) error {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return err
+	}
+
+	return ob.SetInstrument(instrument)
+}
+
+// ProcessLimitOrder routes a limit order to the market of the symbol.
+func (e *Exchange) ProcessLimitOrder(symbol, orderID, traderID string, side Side, amount, price decimal.Decimal, opts ...OrderOption) ([]*Trade, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.ProcessLimitOrder(orderID, traderID, side, amount, price, opts...)
+}
+
+// ProcessMarketOrder routes a market order to the market of the symbol.
+func (e *Exchange) ProcessMarketOrder(symbol, orderID, traderID string, side Side, amount decimal.Decimal, opts ...OrderOption) (*Execution, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.ProcessMarketOrder(orderID, traderID, side, amount, opts...)
+}
+
+// ProcessMarketOrderByFunds routes a market order by funds to the market of the symbol.
+func (e *Exchange) ProcessMarketOrderByFunds(symbol, orderID, traderID string, side Side, funds decimal.Decimal, opts ...OrderOption) (*Execution, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.ProcessMarketOrderByFunds(orderID, traderID, side, funds, opts...)
+}
+
+// ProcessPostOnlyOrder routes a post only order to the market of the symbol.
+func (e *Exchange) ProcessPostOnlyOrder(symbol, orderID, traderID string, side Side, amount, price decimal.Decimal) ([]*Trade, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.ProcessPostOnlyOrder(orderID, traderID, side, amount, price)
+}
+
+// ProcessStopOrder routes a stop order to the market of the symbol.
+func (e *Exchange) ProcessStopOrder(symbol, orderID, traderID string, side Side, amount, stopPrice decimal.Decimal) ([]*Trade, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.ProcessStopOrder(orderID, traderID, side, amount, stopPrice)
+}
+
+// ProcessStopLimitOrder routes a stop limit order to the market of the symbol.
+func (e *Exchange) ProcessStopLimitOrder(symbol, orderID, traderID string, side Side, amount, price, stopPrice decimal.Decimal) ([]*Trade, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.ProcessStopLimitOrder(orderID, traderID, side, amount, price, stopPrice)
+}
+
+// AmendOrder routes an amendment to the market of the symbol.
+func (e *Exchange) AmendOrder(symbol, orderID string, amount, price decimal.Decimal) ([]*Trade, error) {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return ob.AmendOrder(orderID, amount, price)
+}
+
+// CancelOrder routes a cancel to the market of the symbol. It returns nil when the market or the order is not found.
+func (e *Exchange) CancelOrder(symbol, orderID string) *Order {
+	ob, err := e.book(symbol)
+	if err != nil {
+		return nil
+	}
+
+	return ob.CancelOrder(orderID)
+}
+
+// OpenOrders returns the resting and stop orders of a trader by symbol. Markets without orders of the trader are left out.
+func (e *Exchange) OpenOrders(traderID string) map[string][]*Order {
+	defer e.RUnlock()
+	e.RLock()
+
+	orders := make(map[string][]*Order)
+	for symbol, ob := range e.books {
+		if open := ob.OpenOrders(traderID); len(open) > 0 {
+			orders[symbol] = open
+		}
+	}
+
+	return orders
+}
+
+// CancelAll cancels the resting and stop orders of a trader in every market and returns them by symbol.
+func (e *Exchange) CancelAll(traderID string) map[string][]*Order {
+	cancelled := make(map[string][]*Order)
+
+	for symbol, orders := range e.OpenOrders(traderID) {
+		for _, order := range orders {
+			if c := e.CancelOrder(symbol, order.id); c != nil {
+				cancelled[symbol] = append(cancelled[symbol], c)
+			}
+		}
+	}
+
+	return cancelled
+}
+
+func (e *Exchange) book(symbol string) (*OrderBook, error) {
+	defer e.RUnlock()
+	e.RLock()
+
+	ob, ok := e.books[symbol]
+	if !ok {
+		return nil, ErrMarketNotFound
+	}
+
+	return ob, nil
+}
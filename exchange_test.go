@@ -0,0 +1,119 @@
+package orderbook_test
+
+import (
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeMarkets(t *testing.T) {
+	exchange := orderbook.NewExchange(orderbook.WithClock(clock))
+
+	_, err := exchange.AddMarket(" ")
+	assert.Equal(t, orderbook.ErrInvalidSymbol, err)
+
+	btc, err := exchange.AddMarket("BTC/USD")
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC/USD", btc.Symbol())
+
+	_, err = exchange.AddMarket("BTC/USD")
+	assert.Equal(t, orderbook.ErrMarketAlreadyExists, err)
+
+	_, err = exchange.AddMarket("ETH/USD")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"BTC/USD", "ETH/USD"}, exchange.Symbols())
+	assert.Equal(t, btc, exchange.Book("BTC/USD"))
+	assert.Nil(t, exchange.Book("SOL/USD"))
+
+	_, err = exchange.ProcessLimitOrder("SOL/USD", "1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1))
+	assert.Equal(t, orderbook.ErrMarketNotFound, err)
+
+	err = exchange.SetInstrument("SOL/USD", nil)
+	assert.Equal(t, orderbook.ErrMarketNotFound, err)
+
+	instrument := orderbook.NewInstrument(decimal.NewFromInt(5), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+	assert.Nil(t, exchange.SetInstrument("ETH/USD", instrument))
+
+	_, err = exchange.ProcessLimitOrder("ETH/USD", "1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(7))
+	assert.Equal(t, orderbook.ErrInvalidTickSize, err)
+
+	_, err = exchange.ProcessLimitOrder("BTC/USD", "1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(7))
+	assert.Nil(t, err)
+
+	removed, err := exchange.RemoveMarket("BTC/USD")
+	assert.Nil(t, err)
+	assert.Equal(t, btc, removed)
+
+	_, err = exchange.RemoveMarket("BTC/USD")
+	assert.Equal(t, orderbook.ErrMarketNotFound, err)
+	assert.Equal(t, []string{"ETH/USD"}, exchange.Symbols())
+}
+
+func TestExchangeRouting(t *testing.T) {
+	exchange := orderbook.NewExchange(orderbook.WithClock(clock))
+	exchange.AddMarket("BTC/USD")
+	exchange.AddMarket("ETH/USD")
+
+	_, err := exchange.ProcessLimitOrder("BTC/USD", "1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = exchange.ProcessPostOnlyOrder("ETH/USD", "1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(10))
+	assert.Nil(t, err)
+
+	_, err = exchange.ProcessStopOrder("ETH/USD", "2", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(5))
+	assert.Nil(t, err)
+
+	execution, err := exchange.ProcessMarketOrder("BTC/USD", "2", "2", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 1)
+
+	execution, err = exchange.ProcessMarketOrderByFunds("ETH/USD", "3", "2", orderbook.Sell, decimal.NewFromInt(10))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 1)
+
+	_, err = exchange.AmendOrder("BTC/USD", "1", decimal.NewFromInt(1), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+
+	open := exchange.OpenOrders("1")
+	assert.Len(t, open, 2)
+	assert.Len(t, open["BTC/USD"], 1)
+	assert.Equal(t, "101", open["BTC/USD"][0].Price().String())
+	assert.Len(t, open["ETH/USD"], 1)
+	assert.Equal(t, "2", open["ETH/USD"][0].ID())
+	assert.Empty(t, exchange.OpenOrders("2"))
+
+	assert.Nil(t, exchange.CancelOrder("SOL/USD", "1"))
+
+	cancelled := exchange.CancelAll("1")
+	assert.Len(t, cancelled["BTC/USD"], 1)
+	assert.Len(t, cancelled["ETH/USD"], 1)
+	assert.Empty(t, exchange.OpenOrders("1"))
+}
+
+func TestExchangeSetInstrumentConcurrency(t *testing.T) {
+	exchange := orderbook.NewExchange()
+
+	book, err := exchange.AddMarket("BTC/USD")
+	assert.Nil(t, err)
+
+	instrument := orderbook.NewInstrument(decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 100; i++ {
+			assert.Nil(t, exchange.SetInstrument("BTC/USD", instrument))
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		book.Instrument()
+	}
+
+	<-done
+	assert.Equal(t, instrument, book.Instrument())
+}
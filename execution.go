@@ -0,0 +1,82 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*Execution)(nil)
+var _ json.Unmarshaler = (*Execution)(nil)
+
+// Execution represents the outcome of a market order.
+type Execution struct {
+	trades    []*Trade
+	amount    decimal.Decimal
+	notional  decimal.Decimal
+	remaining decimal.Decimal
+}
+
+// NewExecution creates a new execution.
+func NewExecution(trades []*Trade, amount, notional, remaining decimal.Decimal) *Execution {
+	return &Execution{trades, amount, notional, remaining}
+}
+
+// Trades returns the trades.
+func (e *Execution) Trades() []*Trade {
+	return e.trades
+}
+
+// Amount returns the executed amount.
+func (e *Execution) Amount() decimal.Decimal {
+	return e.amount
+}
+
+// Notional returns the executed amount times price, summed over the trades.
+func (e *Execution) Notional() decimal.Decimal {
+	return e.notional
+}
+
+// Remaining returns the unfilled amount, or the unfilled funds of a market order by funds. It is cancelled.
+func (e *Execution) Remaining() decimal.Decimal {
+	return e.remaining
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *Execution) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Trades    []*Trade        `json:"trades"`
+			Amount    decimal.Decimal `json:"amount"`
+			Notional  decimal.Decimal `json:"notional"`
+			Remaining decimal.Decimal `json:"remaining"`
+		}{
+			e.trades,
+			e.amount,
+			e.notional,
+			e.remaining,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Execution) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Trades    []*Trade        `json:"trades"`
+		Amount    decimal.Decimal `json:"amount"`
+		Notional  decimal.Decimal `json:"notional"`
+		Remaining decimal.Decimal `json:"remaining"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("Execution.Unmarshal(%s): %w", data, err)
+	}
+
+	e.trades = obj.Trades
+	e.amount = obj.Amount
+	e.notional = obj.Notional
+	e.remaining = obj.Remaining
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*FeeTier)(nil)
+var _ json.Unmarshaler = (*FeeTier)(nil)
+
+// FeeSchedule computes the fees of the trades executed by an order book.
+type FeeSchedule interface {
+	// Fees returns the fee charged to the maker, the fee charged to the taker and the currency they are charged in.
+	// A negative fee is a rebate.
+	Fees(symbol string, trade *Trade) (makerFee, takerFee decimal.Decimal, currency string)
+}
+
+// FeeTier represents the fee rates applied to the volume of a trade. A negative rate is a rebate.
+type FeeTier struct {
+	makerRate decimal.Decimal
+	takerRate decimal.Decimal
+}
+
+// NewFeeTier creates a new fee tier.
+func NewFeeTier(makerRate, takerRate decimal.Decimal) *FeeTier {
+	return &FeeTier{makerRate, takerRate}
+}
+
+// MakerRate returns the maker rate.
+func (t *FeeTier) MakerRate() decimal.Decimal {
+	return t.makerRate
+}
+
+// TakerRate returns the taker rate.
+func (t *FeeTier) TakerRate() decimal.Decimal {
+	return t.takerRate
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t *FeeTier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			MakerRate decimal.Decimal `json:"makerRate"`
+			TakerRate decimal.Decimal `json:"takerRate"`
+		}{
+			t.makerRate,
+			t.takerRate,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *FeeTier) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		MakerRate decimal.Decimal `json:"makerRate"`
+		TakerRate decimal.Decimal `json:"takerRate"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("FeeTier.Unmarshal(%s): %w", data, err)
+	}
+
+	t.makerRate = obj.MakerRate
+	t.takerRate = obj.TakerRate
+
+	return nil
+}
+
+// TieredFeeSchedule is a fee schedule charging each trade in a single currency, on the notional when it is
+// the quote currency and on the traded amount when it is the base currency.
+// The tier of a trader is used first, then the tier of the symbol and then the default tier.
+type TieredFeeSchedule struct {
+	sync.RWMutex
+	currency    string
+	base        bool
+	defaultTier *FeeTier
+	symbols     map[string]*FeeTier
+	traders     map[string]*FeeTier
+}
+
+// NewTieredFeeSchedule creates a new tiered fee schedule charging the notional in the quote currency.
+func NewTieredFeeSchedule(currency string, defaultTier *FeeTier) *TieredFeeSchedule {
+	return newTieredFeeSchedule(currency, false, defaultTier)
+}
+
+// NewTieredBaseFeeSchedule creates a new tiered fee schedule charging the traded amount in the base currency.
+func NewTieredBaseFeeSchedule(currency string, defaultTier *FeeTier) *TieredFeeSchedule {
+	return newTieredFeeSchedule(currency, true, defaultTier)
+}
+
+func newTieredFeeSchedule(currency string, base bool, defaultTier *FeeTier) *TieredFeeSchedule {
+	return &TieredFeeSchedule{
+		currency:    currency,
+		base:        base,
+		defaultTier: defaultTier,
+		symbols:     make(map[string]*FeeTier),
+		traders:     make(map[string]*FeeTier),
+	}
+}
+
+// Currency returns the currency the fees are charged in.
+func (s *TieredFeeSchedule) Currency() string {
+	return s.currency
+}
+
+// SetSymbolTier overrides the default tier for a symbol. A nil tier removes the override.
+func (s *TieredFeeSchedule) SetSymbolTier(symbol string, tier *FeeTier) {
+	defer s.Unlock()
+	s.Lock()
+
+	if tier == nil {
+		delete(s.symbols, symbol)
+		return
+	}
+
+	s.symbols[symbol] = tier
+}
+
+// SetTraderTier assigns a tier to a trader. A nil tier removes it.
+func (s *TieredFeeSchedule) SetTraderTier(traderID string, tier *FeeTier) {
+	defer s.Unlock()
+	s.Lock()
+
+	if tier == nil {
+		delete(s.traders, traderID)
+		return
+	}
+
+	s.traders[traderID] = tier
+}
+
+// Fees implements FeeSchedule.
+func (s *TieredFeeSchedule) Fees(symbol string, trade *Trade) (decimal.Decimal, decimal.Decimal, string) {
+	defer s.RUnlock()
+	s.RLock()
+
+	volume := trade.amount.Mul(trade.price)
+	if s.base {
+		volume = trade.amount
+	}
+
+	makerFee := volume.Mul(s.tier(symbol, trade.makerTraderID).makerRate)
+	takerFee := volume.Mul(s.tier(symbol, trade.takerTraderID).takerRate)
+
+	return makerFee, takerFee, s.currency
+}
+
+func (s *TieredFeeSchedule) tier(symbol, traderID string) *FeeTier {
+	if tier, ok := s.traders[traderID]; ok {
+		return tier
+	}
+
+	if tier, ok := s.symbols[symbol]; ok {
+		return tier
+	}
+
+	if s.defaultTier != nil {
+		return s.defaultTier
+	}
+
+	return &FeeTier{}
+}
@@ -0,0 +1,70 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeSchedule(t *testing.T) {
+	fees := orderbook.NewTieredFeeSchedule("USD", orderbook.NewFeeTier(decimal.RequireFromString("0.001"), decimal.RequireFromString("0.002")))
+	fees.SetSymbolTier("ETH/USD", orderbook.NewFeeTier(decimal.Zero, decimal.RequireFromString("0.003")))
+	fees.SetTraderTier("mm", orderbook.NewFeeTier(decimal.RequireFromString("-0.0005"), decimal.RequireFromString("0.001")))
+
+	tests := []struct {
+		symbol   string
+		maker    string
+		taker    string
+		makerFee string
+		takerFee string
+	}{
+		{"BTC/USD", "1", "2", "1", "2"},
+		{"ETH/USD", "1", "2", "0", "3"},
+		{"BTC/USD", "mm", "2", "-0.5", "2"},
+		{"ETH/USD", "1", "mm", "0", "1"},
+	}
+
+	for _, tt := range tests {
+		trade := orderbook.NewTrade(1, 1, clock(), "a", "b", tt.taker, tt.maker, orderbook.Buy, decimal.NewFromInt(10), decimal.NewFromInt(100))
+		makerFee, takerFee, currency := fees.Fees(tt.symbol, trade)
+
+		assert.Equal(t, tt.makerFee, makerFee.String(), tt)
+		assert.Equal(t, tt.takerFee, takerFee.String(), tt)
+		assert.Equal(t, "USD", currency)
+	}
+
+	fees.SetTraderTier("mm", nil)
+	makerFee, _, _ := fees.Fees("BTC/USD", orderbook.NewTrade(1, 1, clock(), "a", "b", "2", "mm", orderbook.Buy, decimal.NewFromInt(10), decimal.NewFromInt(100)))
+	assert.Equal(t, "1", makerFee.String())
+}
+
+func TestBaseFeeSchedule(t *testing.T) {
+	fees := orderbook.NewTieredBaseFeeSchedule("BTC", orderbook.NewFeeTier(decimal.RequireFromString("0.001"), decimal.RequireFromString("0.002")))
+
+	trade := orderbook.NewTrade(1, 1, clock(), "a", "b", "2", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	makerFee, takerFee, currency := fees.Fees("BTC/USD", trade)
+
+	assert.Equal(t, "0.001", makerFee.String())
+	assert.Equal(t, "0.002", takerFee.String())
+	assert.Equal(t, "BTC", currency)
+	assert.Equal(t, "BTC", fees.Currency())
+}
+
+func TestTradeFees(t *testing.T) {
+	fees := orderbook.NewTieredFeeSchedule("USD", orderbook.NewFeeTier(decimal.RequireFromString("-0.0001"), decimal.RequireFromString("0.0005")))
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock), orderbook.WithFeeSchedule(fees))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("2", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	s, err := json.Marshal(trades)
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
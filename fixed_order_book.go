@@ -0,0 +1,312 @@
+package orderbook
+
+import (
+	"container/list"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var maxFixed = decimal.NewFromInt(math.MaxInt64)
+
+// FixedOrderBook represents an order book keeping prices as integer ticks and amounts as integer lots of an instrument.
+// Decimals are only converted at the API boundary, so matching does not allocate for arithmetic.
+// It supports GTC limit orders, market orders and cancels, without the order options, events and rules of OrderBook.
+// Resting orders of the taker trader are skipped, like the STPSkip default of OrderBook.
+type FixedOrderBook struct {
+	sync.RWMutex
+	symbol    string
+	tickSize  decimal.Decimal
+	lotSize   decimal.Decimal
+	version   uint64
+	tradeID   uint64
+	lastPrice int64
+	orders    map[string]*list.Element
+	asks      *fixedSide
+	bids      *fixedSide
+	clock     func() time.Time
+}
+
+// NewFixedOrderBook creates a new fixed point order book for the tick and lot size of the instrument.
+// The clock timestamps trades, time.Now when nil.
+func NewFixedOrderBook(symbol string, instrument *Instrument, clock func() time.Time) (*FixedOrderBook, error) {
+	if instrument == nil || !instrument.tickSize.IsPositive() {
+		return nil, ErrInvalidTickSize
+	}
+
+	if !instrument.lotSize.IsPositive() {
+		return nil, ErrInvalidLotSize
+	}
+
+	return &FixedOrderBook{
+		symbol:   symbol,
+		tickSize: instrument.tickSize,
+		lotSize:  instrument.lotSize,
+		orders:   make(map[string]*list.Element),
+		asks:     newFixedSide(Sell),
+		bids:     newFixedSide(Buy),
+		clock:    clock,
+	}, nil
+}
+
+// Symbol returns the symbol.
+func (ob *FixedOrderBook) Symbol() string {
+	return ob.symbol
+}
+
+// Version returns the version. The version is auto incremented by each change.
+func (ob *FixedOrderBook) Version() uint64 {
+	return ob.version
+}
+
+// LastPrice returns the price of the last trade or zero when nothing was traded yet.
+func (ob *FixedOrderBook) LastPrice() decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.price(ob.lastPrice)
+}
+
+// Order returns a copy of a resting order or nil when it is not found.
+func (ob *FixedOrderBook) Order(orderID string) *Order {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	e, ok := ob.orders[orderID]
+	if !ok {
+		return nil
+	}
+
+	return ob.order(e.Value.(*fixedOrder))
+}
+
+// ProcessLimitOrder processes a GTC limit order. The price must be a multiple of the tick size and the amount of the lot size.
+func (ob *FixedOrderBook) ProcessLimitOrder(orderID, traderID string, side Side, amount, price decimal.Decimal) ([]*Trade, error) {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	lots, err := ob.validate(orderID, traderID, side, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if price.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidPrice
+	}
+
+	ticks, err := fixed(price, ob.tickSize, ErrInvalidTickSize, ErrPriceOutOfBounds)
+	if err != nil {
+		return nil, err
+	}
+
+	taker := &fixedOrder{orderID, traderID, side, ticks, lots}
+	fills := ob.match(taker, true)
+
+	if taker.lots > 0 {
+		ob.orders[orderID] = ob.sideOf(side).append(taker)
+	}
+
+	return ob.trades(taker, fills), nil
+}
+
+// ProcessMarketOrder processes a market order. The amount must be a multiple of the lot size.
+func (ob *FixedOrderBook) ProcessMarketOrder(orderID, traderID string, side Side, amount decimal.Decimal) (*Execution, error) {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	lots, err := ob.validate(orderID, traderID, side, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	taker := &fixedOrder{orderID, traderID, side, 0, lots}
+	trades := ob.trades(taker, ob.match(taker, false))
+
+	notional := decimal.Zero
+	for _, trade := range trades {
+		notional = notional.Add(trade.amount.Mul(trade.price))
+	}
+
+	return NewExecution(trades, ob.amount(lots-taker.lots), notional, ob.amount(taker.lots)), nil
+}
+
+// CancelOrder removes a resting order and returns it, nil when it is not found.
+func (ob *FixedOrderBook) CancelOrder(orderID string) *Order {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	e, ok := ob.orders[orderID]
+	if !ok {
+		return nil
+	}
+
+	delete(ob.orders, orderID)
+	return ob.order(ob.sideOf(e.Value.(*fixedOrder).side).remove(e))
+}
+
+// Depth returns the depth, best prices first on both sides.
+func (ob *FixedOrderBook) Depth() *Depth {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	levels := func(side *fixedSide) []*PriceLevel {
+		levels := make([]*PriceLevel, 0, len(side.levels))
+		side.each(func(level *fixedLevel) {
+			levels = append(levels, NewPriceLevel(ob.price(level.ticks), ob.amount(level.lots)))
+		})
+
+		return levels
+	}
+
+	return &Depth{levels(ob.bids), levels(ob.asks), ob.version}
+}
+
+func (ob *FixedOrderBook) validate(orderID, traderID string, side Side, amount decimal.Decimal) (int64, error) {
+	if strings.TrimSpace(orderID) == "" {
+		return 0, ErrInvalidOrderID
+	}
+
+	if ob.orders[orderID] != nil {
+		return 0, ErrOrderAlreadyExists
+	}
+
+	if strings.TrimSpace(traderID) == "" {
+		return 0, ErrInvalidTraderID
+	}
+
+	if side != Buy && side != Sell {
+		return 0, ErrInvalidSide
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return 0, ErrInvalidAmount
+	}
+
+	return fixed(amount, ob.lotSize, ErrInvalidLotSize, ErrAmountTooLarge)
+}
+
+// fixedFill represents a match between a taker and a maker, in ticks and lots.
+type fixedFill struct {
+	makerOrderID  string
+	makerTraderID string
+	ticks         int64
+	lots          int64
+}
+
+// match matches the taker against the opposite side, bounded by its price when limited, and returns the fills.
+// Resting orders of the taker trader are skipped.
+func (ob *FixedOrderBook) match(taker *fixedOrder, limited bool) []fixedFill {
+	opposite := ob.sideOf(taker.side.Opposite())
+	fills := make([]fixedFill, 0)
+
+	for level := opposite.best(); level != nil && taker.lots > 0; level = opposite.after(level.ticks) {
+		if limited && ((taker.side == Buy && level.ticks > taker.ticks) || (taker.side == Sell && level.ticks < taker.ticks)) {
+			break
+		}
+
+		for e := level.orders.Front(); e != nil && taker.lots > 0; {
+			maker := e.Value.(*fixedOrder)
+			next := e.Next()
+
+			if maker.traderID == taker.traderID {
+				e = next
+				continue
+			}
+
+			lots := maker.lots
+			if taker.lots < lots {
+				lots = taker.lots
+			}
+
+			fills = append(fills, fixedFill{maker.id, maker.traderID, maker.ticks, lots})
+			taker.lots -= lots
+			ob.lastPrice = maker.ticks
+
+			if lots == maker.lots {
+				delete(ob.orders, maker.id)
+				opposite.remove(e)
+			} else {
+				maker.lots -= lots
+				level.lots -= lots
+			}
+
+			e = next
+		}
+	}
+
+	return fills
+}
+
+// trades converts the fills of a taker to trades.
+func (ob *FixedOrderBook) trades(taker *fixedOrder, fills []fixedFill) []*Trade {
+	trades := make([]*Trade, 0, len(fills))
+	if len(fills) == 0 {
+		return trades
+	}
+
+	now := ob.now()
+	for _, fill := range fills {
+		ob.tradeID++
+		trades = append(trades, NewTrade(ob.tradeID, ob.version+1, now, taker.id, fill.makerOrderID, taker.traderID, fill.makerTraderID, taker.side, ob.amount(fill.lots), ob.price(fill.ticks)))
+	}
+
+	return trades
+}
+
+func (ob *FixedOrderBook) sideOf(side Side) *fixedSide {
+	if side == Buy {
+		return ob.bids
+	}
+
+	return ob.asks
+}
+
+func (ob *FixedOrderBook) order(order *fixedOrder) *Order {
+	return NewOrder(order.id, order.traderID, order.side, ob.amount(order.lots), ob.price(order.ticks))
+}
+
+func (ob *FixedOrderBook) price(ticks int64) decimal.Decimal {
+	return ob.tickSize.Mul(decimal.NewFromInt(ticks))
+}
+
+func (ob *FixedOrderBook) amount(lots int64) decimal.Decimal {
+	return ob.lotSize.Mul(decimal.NewFromInt(lots))
+}
+
+func (ob *FixedOrderBook) now() time.Time {
+	if ob.clock == nil {
+		return time.Now()
+	}
+
+	return ob.clock()
+}
+
+// fixed converts a value to a whole number of steps. It fails with invalid when the value is not a multiple of the step
+// and with overflow when the steps do not fit an int64.
+func fixed(value, step decimal.Decimal, invalid, overflow error) (int64, error) {
+	q, r := value.QuoRem(step, 0)
+	if !r.IsZero() {
+		return 0, invalid
+	}
+
+	if q.GreaterThan(maxFixed) {
+		return 0, overflow
+	}
+
+	return q.IntPart(), nil
+}
@@ -0,0 +1,219 @@
+package orderbook_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedBook(t testing.TB) *orderbook.FixedOrderBook {
+	instrument := orderbook.NewInstrument(decimal.RequireFromString("0.01"), decimal.RequireFromString("0.1"), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+
+	book, err := orderbook.NewFixedOrderBook("BTC/USD", instrument, clock)
+	assert.Nil(t, err)
+
+	return book
+}
+
+func TestNewFixedOrderBook(t *testing.T) {
+	_, err := orderbook.NewFixedOrderBook("BTC/USD", nil, nil)
+	assert.Equal(t, orderbook.ErrInvalidTickSize, err)
+
+	_, err = orderbook.NewFixedOrderBook("BTC/USD", orderbook.NewInstrument(decimal.NewFromInt(1), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero), nil)
+	assert.Equal(t, orderbook.ErrInvalidLotSize, err)
+}
+
+func TestFixedOrderBookValidations(t *testing.T) {
+	book := fixedBook(t)
+
+	tests := []struct {
+		name     string
+		orderID  string
+		traderID string
+		side     orderbook.Side
+		amount   string
+		price    string
+		err      error
+	}{
+		{"invalid order id", " ", "1", orderbook.Buy, "1", "1", orderbook.ErrInvalidOrderID},
+		{"invalid trader id", "1", " ", orderbook.Buy, "1", "1", orderbook.ErrInvalidTraderID},
+		{"invalid side", "1", "1", orderbook.Side(9), "1", "1", orderbook.ErrInvalidSide},
+		{"invalid amount", "1", "1", orderbook.Buy, "0", "1", orderbook.ErrInvalidAmount},
+		{"invalid price", "1", "1", orderbook.Buy, "1", "0", orderbook.ErrInvalidPrice},
+		{"invalid lot size", "1", "1", orderbook.Buy, "1.05", "1", orderbook.ErrInvalidLotSize},
+		{"invalid tick size", "1", "1", orderbook.Buy, "1", "1.001", orderbook.ErrInvalidTickSize},
+		{"amount too large", "1", "1", orderbook.Buy, "1e30", "1", orderbook.ErrAmountTooLarge},
+		{"price out of bounds", "1", "1", orderbook.Buy, "1", "1e30", orderbook.ErrPriceOutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := book.ProcessLimitOrder(tt.orderID, tt.traderID, tt.side, decimal.RequireFromString(tt.amount), decimal.RequireFromString(tt.price))
+			assert.Equal(t, tt.err, err)
+		})
+	}
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1))
+	assert.Equal(t, orderbook.ErrOrderAlreadyExists, err)
+}
+
+func TestFixedOrderBook(t *testing.T) {
+	book := fixedBook(t)
+
+	for i, price := range []string{"100.5", "100.50", "101", "102"} {
+		id := strconv.Itoa(i)
+		_, err := book.ProcessLimitOrder(id, id, orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString(price))
+		assert.Nil(t, err)
+	}
+
+	_, err := book.ProcessLimitOrder("4", "4", orderbook.Buy, decimal.RequireFromString("0.5"), decimal.NewFromInt(99))
+	assert.Nil(t, err)
+
+	depth := book.Depth()
+	assert.Len(t, depth.Asks(), 3)
+	assert.Equal(t, "100.5", depth.Asks()[0].Price().String())
+	assert.Equal(t, "2", depth.Asks()[0].Amount().String())
+	assert.Equal(t, "102", depth.Asks()[2].Price().String())
+	assert.Equal(t, "99", depth.Bids()[0].Price().String())
+
+	trades, err := book.ProcessLimitOrder("5", "5", orderbook.Buy, decimal.RequireFromString("2.5"), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 3)
+	assert.Equal(t, "0", trades[0].MakerOrderID())
+	assert.Equal(t, "1", trades[1].MakerOrderID())
+	assert.Equal(t, "2", trades[2].MakerOrderID())
+	assert.Equal(t, "0.5", trades[2].Amount().String())
+	assert.Equal(t, uint64(3), trades[2].ID())
+	assert.Equal(t, clock(), trades[2].Time())
+	assert.Equal(t, "101", book.LastPrice().String())
+	assert.Equal(t, "0.5", book.Order("2").Amount().String())
+	assert.Nil(t, book.Order("5"))
+
+	execution, err := book.ProcessMarketOrder("6", "6", orderbook.Buy, decimal.NewFromInt(2))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 2)
+	assert.Equal(t, "1.5", execution.Amount().String())
+	assert.Equal(t, "152.5", execution.Notional().String())
+	assert.Equal(t, "0.5", execution.Remaining().String())
+	assert.Empty(t, book.Depth().Asks())
+
+	order := book.CancelOrder("4")
+	assert.Equal(t, "99", order.Price().String())
+	assert.Equal(t, "0.5", order.Amount().String())
+	assert.Nil(t, book.CancelOrder("4"))
+	assert.Empty(t, book.Depth().Bids())
+}
+
+func TestFixedOrderBookParity(t *testing.T) {
+	instrument := orderbook.NewInstrument(decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+
+	fixed, err := orderbook.NewFixedOrderBook("BTC/USD", instrument, clock)
+	assert.Nil(t, err)
+
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithInstrument(instrument), orderbook.WithClock(clock))
+
+	fills := func(trades []*orderbook.Trade) []string {
+		fills := make([]string, 0, len(trades))
+		for _, trade := range trades {
+			fills = append(fills, trade.TakerOrderID()+"/"+trade.MakerOrderID()+"/"+trade.Amount().String()+"@"+trade.Price().String())
+		}
+
+		return fills
+	}
+
+	r := rand.New(rand.NewSource(1))
+
+	for i, o := range benchmarkOrders(2000) {
+		traderID := strconv.Itoa(r.Intn(5))
+
+		if i%10 == 0 {
+			fixedExecution, err := fixed.ProcessMarketOrder(o.id, traderID, o.side, o.amount)
+			assert.Nil(t, err)
+
+			execution, err := book.ProcessMarketOrder(o.id, traderID, o.side, o.amount)
+			assert.Nil(t, err)
+
+			assert.Equal(t, fills(execution.Trades()), fills(fixedExecution.Trades()))
+			continue
+		}
+
+		fixedTrades, err := fixed.ProcessLimitOrder(o.id, traderID, o.side, o.amount, o.price)
+		assert.Nil(t, err)
+
+		trades, err := book.ProcessLimitOrder(o.id, traderID, o.side, o.amount, o.price)
+		assert.Nil(t, err)
+
+		assert.Equal(t, fills(trades), fills(fixedTrades))
+	}
+
+	depth, fixedDepth := book.Depth(), fixed.Depth()
+	assert.Equal(t, len(depth.Bids()), len(fixedDepth.Bids()))
+	assert.Equal(t, len(depth.Asks()), len(fixedDepth.Asks()))
+
+	for i, level := range depth.Bids() {
+		assert.True(t, level.Price().Equal(fixedDepth.Bids()[i].Price()))
+		assert.True(t, level.Amount().Equal(fixedDepth.Bids()[i].Amount()))
+	}
+
+	for i, level := range depth.Asks() {
+		assert.True(t, level.Price().Equal(fixedDepth.Asks()[i].Price()))
+		assert.True(t, level.Amount().Equal(fixedDepth.Asks()[i].Amount()))
+	}
+}
+
+type benchmarkOrder struct {
+	id     string
+	side   orderbook.Side
+	amount decimal.Decimal
+	price  decimal.Decimal
+}
+
+func benchmarkOrders(l int) []benchmarkOrder {
+	r := rand.New(rand.NewSource(1))
+	orders := make([]benchmarkOrder, l)
+
+	for i := range orders {
+		side := orderbook.Buy
+		if r.Intn(2) == 0 {
+			side = orderbook.Sell
+		}
+
+		orders[i] = benchmarkOrder{strconv.Itoa(i), side, decimal.NewFromInt(int64(r.Intn(100) + 1)), decimal.NewFromInt(int64(r.Intn(100) + 1))}
+	}
+
+	return orders
+}
+
+func benchmarkMatching(l int, fixed bool, b *testing.B) {
+	orders := benchmarkOrders(l)
+	instrument := orderbook.NewInstrument(decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if fixed {
+			book, _ := orderbook.NewFixedOrderBook("USD/BTC", instrument, nil)
+			for _, o := range orders {
+				book.ProcessLimitOrder(o.id, o.id, o.side, o.amount, o.price)
+			}
+		} else {
+			book := orderbook.NewOrderBook("USD/BTC")
+			for _, o := range orders {
+				book.ProcessLimitOrder(o.id, o.id, o.side, o.amount, o.price)
+			}
+		}
+	}
+}
+
+func BenchmarkMatching1000(b *testing.B)       { benchmarkMatching(1000, false, b) }
+func BenchmarkMatching10000(b *testing.B)      { benchmarkMatching(10000, false, b) }
+func BenchmarkFixedMatching1000(b *testing.B)  { benchmarkMatching(1000, true, b) }
+func BenchmarkFixedMatching10000(b *testing.B) { benchmarkMatching(10000, true, b) }
@@ -0,0 +1,113 @@
+package orderbook
+
+import (
+	"container/list"
+
+	"github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/emirpasic/gods/utils"
+)
+
+// fixedOrder represents a resting order of a FixedOrderBook, in ticks and lots.
+type fixedOrder struct {
+	id       string
+	traderID string
+	side     Side
+	ticks    int64
+	lots     int64
+}
+
+// fixedLevel represents the orders resting at a price, in time priority.
+type fixedLevel struct {
+	ticks  int64
+	lots   int64
+	orders *list.List
+}
+
+// fixedSide represents all the price levels about bids or asks, keyed by ticks.
+type fixedSide struct {
+	side   Side
+	tree   *redblacktree.Tree
+	levels map[int64]*fixedLevel
+}
+
+func newFixedSide(side Side) *fixedSide {
+	return &fixedSide{side, redblacktree.NewWith(utils.Int64Comparator), make(map[int64]*fixedLevel)}
+}
+
+func (fs *fixedSide) append(order *fixedOrder) *list.Element {
+	level, ok := fs.levels[order.ticks]
+	if !ok {
+		level = &fixedLevel{order.ticks, 0, list.New()}
+		fs.levels[order.ticks] = level
+		fs.tree.Put(order.ticks, level)
+	}
+
+	level.lots += order.lots
+	return level.orders.PushBack(order)
+}
+
+func (fs *fixedSide) remove(e *list.Element) *fixedOrder {
+	order := e.Value.(*fixedOrder)
+	level := fs.levels[order.ticks]
+
+	level.orders.Remove(e)
+	level.lots -= order.lots
+
+	if level.orders.Len() == 0 {
+		delete(fs.levels, order.ticks)
+		fs.tree.Remove(order.ticks)
+	}
+
+	return order
+}
+
+// best returns the highest bid or the lowest ask level, nil when the side is empty.
+func (fs *fixedSide) best() *fixedLevel {
+	var node *redblacktree.Node
+	if fs.side == Buy {
+		node = fs.tree.Right()
+	} else {
+		node = fs.tree.Left()
+	}
+
+	if node == nil {
+		return nil
+	}
+
+	return node.Value.(*fixedLevel)
+}
+
+// after returns the next level worse than the ticks, nil when there is none.
+func (fs *fixedSide) after(ticks int64) *fixedLevel {
+	var (
+		node  *redblacktree.Node
+		found bool
+	)
+
+	if fs.side == Buy {
+		node, found = fs.tree.Floor(ticks - 1)
+	} else {
+		node, found = fs.tree.Ceiling(ticks + 1)
+	}
+
+	if !found {
+		return nil
+	}
+
+	return node.Value.(*fixedLevel)
+}
+
+// each calls fn with every level, best first.
+func (fs *fixedSide) each(fn func(level *fixedLevel)) {
+	it := fs.tree.Iterator()
+
+	if fs.side == Buy {
+		for it.End(); it.Prev(); {
+			fn(it.Value().(*fixedLevel))
+		}
+	} else {
+		for it.Begin(); it.Next(); {
+			fn(it.Value().(*fixedLevel))
+		}
+	}
+}
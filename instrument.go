@@ -0,0 +1,171 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*Instrument)(nil)
+var _ json.Unmarshaler = (*Instrument)(nil)
+
+// Instrument represents the trading rules of a market. A zero rule is not checked.
+type Instrument struct {
+	tickSize    decimal.Decimal
+	lotSize     decimal.Decimal
+	minAmount   decimal.Decimal
+	maxAmount   decimal.Decimal
+	minNotional decimal.Decimal
+	minPrice    decimal.Decimal
+	maxPrice    decimal.Decimal
+}
+
+// NewInstrument creates a new instrument.
+func NewInstrument(tickSize, lotSize, minAmount, maxAmount, minNotional, minPrice, maxPrice decimal.Decimal) *Instrument {
+	return &Instrument{tickSize, lotSize, minAmount, maxAmount, minNotional, minPrice, maxPrice}
+}
+
+// TickSize returns the price step.
+func (i *Instrument) TickSize() decimal.Decimal {
+	return i.tickSize
+}
+
+// LotSize returns the amount step.
+func (i *Instrument) LotSize() decimal.Decimal {
+	return i.lotSize
+}
+
+// MinAmount returns the min amount of an order.
+func (i *Instrument) MinAmount() decimal.Decimal {
+	return i.minAmount
+}
+
+// MaxAmount returns the max amount of an order.
+func (i *Instrument) MaxAmount() decimal.Decimal {
+	return i.maxAmount
+}
+
+// MinNotional returns the min amount times price of an order.
+func (i *Instrument) MinNotional() decimal.Decimal {
+	return i.minNotional
+}
+
+// MinPrice returns the min price of an order.
+func (i *Instrument) MinPrice() decimal.Decimal {
+	return i.minPrice
+}
+
+// MaxPrice returns the max price of an order.
+func (i *Instrument) MaxPrice() decimal.Decimal {
+	return i.maxPrice
+}
+
+// Validate checks the amount and price of a limit order against the rules.
+func (i *Instrument) Validate(amount, price decimal.Decimal) error {
+	if err := i.ValidateAmount(amount); err != nil {
+		return err
+	}
+
+	if err := i.ValidatePrice(price); err != nil {
+		return err
+	}
+
+	if i != nil && i.minNotional.IsPositive() && amount.Mul(price).LessThan(i.minNotional) {
+		return ErrNotionalTooSmall
+	}
+
+	return nil
+}
+
+// ValidateAmount checks an amount against the rules.
+func (i *Instrument) ValidateAmount(amount decimal.Decimal) error {
+	if i == nil {
+		return nil
+	}
+
+	if i.lotSize.IsPositive() && !amount.Mod(i.lotSize).IsZero() {
+		return ErrInvalidLotSize
+	}
+
+	if i.minAmount.IsPositive() && amount.LessThan(i.minAmount) {
+		return ErrAmountTooSmall
+	}
+
+	if i.maxAmount.IsPositive() && amount.GreaterThan(i.maxAmount) {
+		return ErrAmountTooLarge
+	}
+
+	return nil
+}
+
+// ValidatePrice checks a price against the rules.
+func (i *Instrument) ValidatePrice(price decimal.Decimal) error {
+	if i == nil {
+		return nil
+	}
+
+	if i.tickSize.IsPositive() && !price.Mod(i.tickSize).IsZero() {
+		return ErrInvalidTickSize
+	}
+
+	if i.minPrice.IsPositive() && price.LessThan(i.minPrice) {
+		return ErrPriceOutOfBounds
+	}
+
+	if i.maxPrice.IsPositive() && price.GreaterThan(i.maxPrice) {
+		return ErrPriceOutOfBounds
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i *Instrument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			TickSize    decimal.Decimal `json:"tickSize"`
+			LotSize     decimal.Decimal `json:"lotSize"`
+			MinAmount   decimal.Decimal `json:"minAmount"`
+			MaxAmount   decimal.Decimal `json:"maxAmount"`
+			MinNotional decimal.Decimal `json:"minNotional"`
+			MinPrice    decimal.Decimal `json:"minPrice"`
+			MaxPrice    decimal.Decimal `json:"maxPrice"`
+		}{
+			i.tickSize,
+			i.lotSize,
+			i.minAmount,
+			i.maxAmount,
+			i.minNotional,
+			i.minPrice,
+			i.maxPrice,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Instrument) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		TickSize    decimal.Decimal `json:"tickSize"`
+		LotSize     decimal.Decimal `json:"lotSize"`
+		MinAmount   decimal.Decimal `json:"minAmount"`
+		MaxAmount   decimal.Decimal `json:"maxAmount"`
+		MinNotional decimal.Decimal `json:"minNotional"`
+		MinPrice    decimal.Decimal `json:"minPrice"`
+		MaxPrice    decimal.Decimal `json:"maxPrice"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("Instrument.Unmarshal(%s): %w", data, err)
+	}
+
+	i.tickSize = obj.TickSize
+	i.lotSize = obj.LotSize
+	i.minAmount = obj.MinAmount
+	i.maxAmount = obj.MaxAmount
+	i.minNotional = obj.MinNotional
+	i.minPrice = obj.MinPrice
+	i.maxPrice = obj.MaxPrice
+
+	return nil
+}
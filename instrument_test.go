@@ -0,0 +1,60 @@
+package orderbook_test
+
+import (
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentRules(t *testing.T) {
+	instrument := orderbook.NewInstrument(
+		decimal.RequireFromString("0.01"),
+		decimal.RequireFromString("0.001"),
+		decimal.RequireFromString("0.01"),
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(10),
+		decimal.NewFromInt(1),
+		decimal.NewFromInt(100000),
+	)
+
+	tests := []struct {
+		name   string
+		amount string
+		price  string
+		err    error
+	}{
+		{name: "valid", amount: "1.5", price: "100.01", err: nil},
+		{name: "invalid tick size", amount: "1", price: "100.001", err: orderbook.ErrInvalidTickSize},
+		{name: "invalid lot size", amount: "1.0005", price: "100", err: orderbook.ErrInvalidLotSize},
+		{name: "amount too small", amount: "0.005", price: "10000", err: orderbook.ErrAmountTooSmall},
+		{name: "amount too large", amount: "101", price: "100", err: orderbook.ErrAmountTooLarge},
+		{name: "notional too small", amount: "0.05", price: "100", err: orderbook.ErrNotionalTooSmall},
+		{name: "price too low", amount: "50", price: "0.5", err: orderbook.ErrPriceOutOfBounds},
+		{name: "price too high", amount: "1", price: "100001", err: orderbook.ErrPriceOutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := orderbook.NewOrderBook("BTC/USD", orderbook.WithInstrument(instrument))
+
+			_, err := book.ProcessLimitOrder("1", "1", orderbook.Buy, decimal.RequireFromString(tt.amount), decimal.RequireFromString(tt.price))
+			assert.Equal(t, tt.err, err)
+
+			_, err = book.ProcessPostOnlyOrder("2", "2", orderbook.Buy, decimal.RequireFromString(tt.amount), decimal.RequireFromString(tt.price))
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestInstrumentRulesMarketOrder(t *testing.T) {
+	instrument := orderbook.NewInstrument(decimal.Zero, decimal.NewFromInt(1), decimal.Zero, decimal.Zero, decimal.NewFromInt(1000), decimal.Zero, decimal.Zero)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithInstrument(instrument))
+
+	_, err := book.ProcessMarketOrder("1", "1", orderbook.Buy, decimal.RequireFromString("1.5"))
+	assert.Equal(t, orderbook.ErrInvalidLotSize, err)
+
+	_, err = book.ProcessMarketOrder("2", "2", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,136 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WithJournal records every accepted command to the writer, one JSON command per line, before it is applied.
+// A command is rejected when it can not be recorded.
+func WithJournal(w io.Writer) Option {
+	return func(ob *OrderBook) {
+		ob.journal = json.NewEncoder(w)
+	}
+}
+
+// record writes a command to the journal, tagged with the version it produces and the clock time.
+func (ob *OrderBook) record(cmd *Command) error {
+	ob.recorded = true
+
+	if ob.journal == nil || ob.replaying {
+		return nil
+	}
+
+	cmd.version = ob.version + 1
+	if cmd.time.IsZero() {
+		cmd.time = ob.now()
+	}
+
+	if err := ob.journal.Encode(cmd); err != nil {
+		return fmt.Errorf("OrderBook.record(%s): %w", cmd.commandType, err)
+	}
+
+	return nil
+}
+
+// Replay rebuilds an order book from a snapshot, as produced by MarshalJSON, and the journal tail.
+// Commands with a version not greater than the snapshot version are skipped.
+// Trade checks run again while replaying, so pass the same ones, in the state they had at the snapshot, to stop matching where it stopped.
+func Replay(snapshot []byte, journal io.Reader, opts ...Option) (*OrderBook, error) {
+	ob := NewOrderBook("", opts...)
+
+	if err := json.Unmarshal(snapshot, ob); err != nil {
+		return nil, fmt.Errorf("Replay: %w", err)
+	}
+
+	clock := ob.clock
+	ob.replaying = true
+
+	defer func() {
+		ob.clock = clock
+		ob.replaying = false
+	}()
+
+	decoder := json.NewDecoder(journal)
+
+	for {
+		var cmd Command
+
+		if err := decoder.Decode(&cmd); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("Replay: %w", err)
+		}
+
+		if cmd.version <= ob.version {
+			continue
+		}
+
+		if err := ob.apply(&cmd); err != nil {
+			return nil, fmt.Errorf("Replay(%d): %w", cmd.version, err)
+		}
+	}
+
+	return ob, nil
+}
+
+// apply applies a journaled command at its version and clock time.
+// An error returned once the command got past its validation, like a failed trade check, is part of its outcome and ignored.
+func (ob *OrderBook) apply(cmd *Command) error {
+	var err error
+
+	now := cmd.time
+	ob.clock = func() time.Time { return now }
+	ob.version = cmd.version - 1
+	ob.recorded = false
+
+	opts := []OrderOption{
+		WithTimeInForce(cmd.options.timeInForce),
+		WithExpireTime(cmd.options.expireAt),
+		WithDisplayAmount(cmd.options.displayAmount),
+	}
+
+	if cmd.options.selfTradePrevention != nil {
+		opts = append(opts, WithSelfTradePrevention(*cmd.options.selfTradePrevention))
+	}
+
+	switch cmd.commandType {
+	case LimitCommand:
+		_, err = ob.ProcessLimitOrder(cmd.orderID, cmd.traderID, cmd.side, cmd.amount, cmd.price, opts...)
+	case MarketCommand:
+		_, err = ob.ProcessMarketOrder(cmd.orderID, cmd.traderID, cmd.side, cmd.amount, opts...)
+	case MarketFundsCommand:
+		_, err = ob.ProcessMarketOrderByFunds(cmd.orderID, cmd.traderID, cmd.side, cmd.funds, opts...)
+	case PostOnlyCommand:
+		_, err = ob.ProcessPostOnlyOrder(cmd.orderID, cmd.traderID, cmd.side, cmd.amount, cmd.price)
+	case StopCommand:
+		_, err = ob.ProcessStopOrder(cmd.orderID, cmd.traderID, cmd.side, cmd.amount, cmd.stopPrice)
+	case StopLimitCommand:
+		_, err = ob.ProcessStopLimitOrder(cmd.orderID, cmd.traderID, cmd.side, cmd.amount, cmd.price, cmd.stopPrice)
+	case CancelCommand:
+		_, err = ob.CancelOrderErr(cmd.orderID)
+	case AmendCommand:
+		_, err = ob.AmendOrder(cmd.orderID, cmd.amount, cmd.price)
+	case ExpireCommand:
+		ob.ExpireOrders(cmd.time)
+	case StatusCommand:
+		err = ob.SetStatus(cmd.status)
+	case UncrossCommand:
+		_, err = ob.Uncross()
+	case InstrumentCommand:
+		err = ob.SetInstrument(cmd.instrument)
+	default:
+		err = fmt.Errorf("unknown command %s", cmd.commandType)
+	}
+
+	if ob.recorded {
+		return nil
+	}
+
+	return err
+}
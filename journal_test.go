@@ -0,0 +1,160 @@
+package orderbook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestReplay(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	var journal bytes.Buffer
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock), orderbook.WithJournal(&journal))
+
+	_, err := book.ProcessPostOnlyOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(5), decimal.NewFromInt(110), orderbook.WithDisplayAmount(decimal.NewFromInt(1)))
+	assert.Nil(t, err)
+
+	snapshot, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("3", "3", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90),
+		orderbook.WithTimeInForce(orderbook.GTD), orderbook.WithExpireTime(now.Add(time.Minute)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessStopOrder("4", "4", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(105))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("5", "5", orderbook.Sell, decimal.NewFromInt(0), decimal.NewFromInt(90))
+	assert.Equal(t, orderbook.ErrInvalidAmount, err)
+
+	_, err = book.ProcessMarketOrder("6", "6", orderbook.Buy, decimal.NewFromInt(2))
+	assert.Nil(t, err)
+
+	_, err = book.AmendOrder("2", decimal.NewFromInt(3), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, book.CancelOrder("4"))
+
+	now = now.Add(time.Hour)
+	assert.Len(t, book.ExpireOrders(now), 1)
+
+	replayed, err := orderbook.Replay(snapshot, bytes.NewReader(journal.Bytes()))
+	assert.Nil(t, err)
+
+	expected, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	actual, err := json.Marshal(replayed)
+	assert.Nil(t, err)
+
+	assert.JSONEq(t, string(expected), string(actual))
+	cupaloy.SnapshotT(t, journal.String())
+}
+
+func TestReplayInstrument(t *testing.T) {
+	var journal bytes.Buffer
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock), orderbook.WithJournal(&journal))
+
+	snapshot, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	instrument := orderbook.NewInstrument(decimal.NewFromInt(1), decimal.RequireFromString("0.1"), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+	assert.Nil(t, book.SetInstrument(instrument))
+
+	_, err = book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	replayed, err := orderbook.Replay(snapshot, bytes.NewReader(journal.Bytes()), orderbook.WithClock(clock))
+	assert.Nil(t, err)
+	assert.Equal(t, book.Version(), replayed.Version())
+	assert.Equal(t, "1", replayed.Instrument().TickSize().String())
+	assert.Equal(t, "0.1", replayed.Instrument().LotSize().String())
+
+	_, err = replayed.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString("100.5"))
+	assert.Equal(t, orderbook.ErrInvalidTickSize, err)
+}
+
+func TestReplayInstrumentSnapshot(t *testing.T) {
+	var journal bytes.Buffer
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock), orderbook.WithJournal(&journal))
+
+	instrument := orderbook.NewInstrument(decimal.NewFromInt(1), decimal.RequireFromString("0.1"), decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero)
+	assert.Nil(t, book.SetInstrument(instrument))
+
+	snapshot, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	replayed, err := orderbook.Replay(snapshot, bytes.NewReader(nil), orderbook.WithClock(clock))
+	assert.Nil(t, err)
+	assert.Equal(t, "1", replayed.Instrument().TickSize().String())
+	assert.Equal(t, "0.1", replayed.Instrument().LotSize().String())
+
+	_, err = replayed.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString("100.5"))
+	assert.Equal(t, orderbook.ErrInvalidTickSize, err)
+}
+
+func TestReplayTradeChecks(t *testing.T) {
+	market := func() *orderbook.MarketLedger {
+		ledger := orderbook.NewLedger()
+		assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(2)))
+		assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.RequireFromString("100.5")))
+		return ledger.Market("BTC", "USD")
+	}
+
+	fees := orderbook.NewTieredFeeSchedule("USD", orderbook.NewFeeTier(decimal.Zero, decimal.RequireFromString("0.01")))
+
+	var journal bytes.Buffer
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock), orderbook.WithJournal(&journal), orderbook.WithLedger(market()), orderbook.WithFeeSchedule(fees))
+
+	snapshot, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("1", "seller", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(50))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "seller", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(50))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("3", "buyer", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(50))
+	assert.Equal(t, orderbook.ErrInsufficientFunds, err)
+	assert.Len(t, trades, 1)
+
+	replayed, err := orderbook.Replay(snapshot, bytes.NewReader(journal.Bytes()), orderbook.WithLedger(market()), orderbook.WithFeeSchedule(fees))
+	assert.Nil(t, err)
+
+	expected, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	actual, err := json.Marshal(replayed)
+	assert.Nil(t, err)
+
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Len(t, replayed.Depth().Asks(), 1)
+}
+
+func TestJournalFailure(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithJournal(failingWriter{}))
+
+	trades, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, trades)
+	assert.NotNil(t, err)
+	assert.Empty(t, book.Depth().Asks())
+}
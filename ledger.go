@@ -0,0 +1,412 @@
+package orderbook
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Ledger holds the balances of the traders in every currency. It is shared by the markets settling in it.
+type Ledger struct {
+	sync.Mutex
+	accounts map[string]map[string]*Balance
+}
+
+// NewLedger creates a new ledger.
+func NewLedger() *Ledger {
+	return &Ledger{accounts: make(map[string]map[string]*Balance)}
+}
+
+// Deposit adds available funds to a trader.
+func (l *Ledger) Deposit(traderID, currency string, amount decimal.Decimal) error {
+	defer l.Unlock()
+	l.Lock()
+
+	if strings.TrimSpace(traderID) == "" {
+		return ErrInvalidTraderID
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return ErrInvalidAmount
+	}
+
+	b := l.account(traderID, currency)
+	b.available = b.available.Add(amount)
+
+	return nil
+}
+
+// Withdraw removes available funds from a trader.
+func (l *Ledger) Withdraw(traderID, currency string, amount decimal.Decimal) error {
+	defer l.Unlock()
+	l.Lock()
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return ErrInvalidAmount
+	}
+
+	b := l.account(traderID, currency)
+	if b.available.LessThan(amount) {
+		return ErrInsufficientFunds
+	}
+
+	b.available = b.available.Sub(amount)
+	return nil
+}
+
+// Balance returns a copy of the balance of a trader in a currency.
+func (l *Ledger) Balance(traderID, currency string) *Balance {
+	defer l.Unlock()
+	l.Lock()
+
+	if b, ok := l.accounts[traderID][currency]; ok {
+		return NewBalance(b.available, b.held)
+	}
+
+	return NewBalance(decimal.Zero, decimal.Zero)
+}
+
+// Balances returns a copy of the balances of a trader by currency.
+func (l *Ledger) Balances(traderID string) map[string]*Balance {
+	defer l.Unlock()
+	l.Lock()
+
+	balances := make(map[string]*Balance)
+	for currency, b := range l.accounts[traderID] {
+		balances[currency] = NewBalance(b.available, b.held)
+	}
+
+	return balances
+}
+
+// Market creates the settlement of an order book trading the base currency against the quote currency.
+func (l *Ledger) Market(base, quote string) *MarketLedger {
+	return &MarketLedger{l, base, quote, make(map[string]*hold), nil}
+}
+
+func (l *Ledger) account(traderID, currency string) *Balance {
+	balances, ok := l.accounts[traderID]
+	if !ok {
+		balances = make(map[string]*Balance)
+		l.accounts[traderID] = balances
+	}
+
+	b, ok := balances[currency]
+	if !ok {
+		b = NewBalance(decimal.Zero, decimal.Zero)
+		balances[currency] = b
+	}
+
+	return b
+}
+
+// hold represents the funds held by an order.
+type hold struct {
+	traderID string
+	currency string
+	amount   decimal.Decimal
+	pending  bool
+}
+
+// MarketLedger holds the funds of the orders of an order book in a ledger and settles its trades.
+// Buy orders hold the quote currency and sell orders the base currency.
+//
+// It is both a risk check, holding the funds of each order before it is accepted, and a listener,
+// settling the trades and releasing the unused funds when an order is filled, rests or is cancelled.
+// Register it with WithLedger. Funds of market orders by amount, and of sell orders by funds, are estimated from the depth;
+// a trade the holds and the available funds can not pay for, fees included, fails before it executes, with ErrMakerInsufficientFunds
+// when the maker is short and ErrInsufficientFunds otherwise. Resting orders also hold their estimated maker fee when it is
+// charged in the currency they hold. The hold of an order reduced in place shrinks with it.
+type MarketLedger struct {
+	ledger *Ledger
+	base   string
+	quote  string
+	holds  map[string]*hold
+	book   *OrderBook
+}
+
+// Base returns the base currency.
+func (m *MarketLedger) Base() string {
+	return m.base
+}
+
+// Quote returns the quote currency.
+func (m *MarketLedger) Quote() string {
+	return m.quote
+}
+
+// Held returns the funds held by an order.
+func (m *MarketLedger) Held(orderID string) decimal.Decimal {
+	defer m.ledger.Unlock()
+	m.ledger.Lock()
+
+	if h, ok := m.holds[orderID]; ok {
+		return h.amount
+	}
+
+	return decimal.Zero
+}
+
+// Check implements RiskCheck. It holds the funds of a new order and checks an amended order is still covered.
+func (m *MarketLedger) Check(order *Order, book BookView) error {
+	currency, amount := m.required(order, book)
+
+	defer m.ledger.Unlock()
+	m.ledger.Lock()
+
+	b := m.ledger.account(order.traderID, currency)
+
+	if h, ok := m.holds[order.id]; ok && book.Order(order.id) != nil {
+		if b.available.Add(h.amount).LessThan(amount) {
+			return ErrInsufficientFunds
+		}
+
+		return nil
+	}
+
+	if b.available.LessThan(amount) {
+		return ErrInsufficientFunds
+	}
+
+	h := &hold{order.traderID, currency, decimal.Zero, true}
+	m.holds[order.id] = h
+	m.reserve(h, amount)
+
+	return nil
+}
+
+// CheckTrade implements TradeCheck. It rejects a trade a trader can not pay for from the holds of its orders and the available funds,
+// blaming the maker first.
+func (m *MarketLedger) CheckTrade(trade *Trade) error {
+	defer m.ledger.Unlock()
+	m.ledger.Lock()
+
+	type account struct {
+		traderID string
+		currency string
+	}
+
+	_, buyerID, _, sellerID := m.parties(trade)
+	notional := trade.amount.Mul(trade.price)
+
+	owed := make(map[account]decimal.Decimal)
+	owed[account{buyerID, m.quote}] = owed[account{buyerID, m.quote}].Add(notional)
+	owed[account{buyerID, m.base}] = owed[account{buyerID, m.base}].Sub(trade.amount)
+	owed[account{sellerID, m.base}] = owed[account{sellerID, m.base}].Add(trade.amount)
+	owed[account{sellerID, m.quote}] = owed[account{sellerID, m.quote}].Sub(notional)
+
+	if trade.feeCurrency != "" {
+		owed[account{trade.makerTraderID, trade.feeCurrency}] = owed[account{trade.makerTraderID, trade.feeCurrency}].Add(trade.makerFee)
+		owed[account{trade.takerTraderID, trade.feeCurrency}] = owed[account{trade.takerTraderID, trade.feeCurrency}].Add(trade.takerFee)
+	}
+
+	var err error
+
+	for a, amount := range owed {
+		if !amount.IsPositive() {
+			continue
+		}
+
+		have := m.ledger.account(a.traderID, a.currency).available
+		for _, orderID := range []string{trade.takerOrderID, trade.makerOrderID} {
+			if h, ok := m.holds[orderID]; ok && h.traderID == a.traderID && h.currency == a.currency {
+				have = have.Add(h.amount)
+			}
+		}
+
+		if have.GreaterThanOrEqual(amount) {
+			continue
+		}
+
+		if a.traderID == trade.makerTraderID && a.traderID != trade.takerTraderID {
+			return ErrMakerInsufficientFunds
+		}
+
+		err = ErrInsufficientFunds
+	}
+
+	return err
+}
+
+// OnEvent implements Listener.
+func (m *MarketLedger) OnEvent(event *Event) {
+	defer m.ledger.Unlock()
+	m.ledger.Lock()
+
+	switch event.eventType {
+	case OrderAccepted:
+		if h, ok := m.holds[event.order.id]; ok {
+			h.pending = false
+		}
+	case OrderRejected:
+		if h, ok := m.holds[event.order.id]; ok && h.pending {
+			m.release(event.order.id)
+		}
+	case OrderRested, OrderAmended:
+		m.rest(event.order)
+	case OrderFilled, OrderCancelled:
+		m.release(event.order.id)
+	case TradeExecuted:
+		m.settle(event.trade)
+	}
+}
+
+// required returns the currency and amount an order holds.
+func (m *MarketLedger) required(order *Order, book BookView) (string, decimal.Decimal) {
+	if order.side == Buy {
+		switch {
+		case order.funds.IsPositive():
+			return m.quote, order.funds
+		case order.price.IsPositive():
+			return m.quote, order.amount.Add(order.hiddenAmount).Mul(order.price)
+		default:
+			_, notional := walk(book.Depth().Asks(), order.amount, decimal.Zero)
+			return m.quote, notional
+		}
+	}
+
+	if order.funds.IsPositive() {
+		amount, _ := walk(book.Depth().Bids(), decimal.Zero, order.funds)
+		return m.base, amount
+	}
+
+	return m.base, order.amount.Add(order.hiddenAmount)
+}
+
+// rest sets the hold of a resting order to its remaining amount plus its estimated maker fee, as far as the available funds go.
+func (m *MarketLedger) rest(order *Order) {
+	currency, amount := m.base, order.amount.Add(order.hiddenAmount)
+	if order.side == Buy {
+		currency, amount = m.quote, amount.Mul(order.price)
+	}
+
+	h, ok := m.holds[order.id]
+	if !ok {
+		h = &hold{order.traderID, currency, decimal.Zero, false}
+		m.holds[order.id] = h
+	}
+
+	h.pending = false
+	m.reserve(h, amount.Sub(h.amount))
+
+	if fee := m.makerFee(order, currency); fee.IsPositive() {
+		available := m.ledger.account(h.traderID, h.currency).available
+		m.reserve(h, decimal.Min(fee, decimal.Max(available, decimal.Zero)))
+	}
+}
+
+// makerFee estimates the fee a resting order pays when it is filled, zero when it is charged in another currency.
+func (m *MarketLedger) makerFee(order *Order, currency string) decimal.Decimal {
+	if m.book == nil || m.book.fees == nil {
+		return decimal.Zero
+	}
+
+	trade := NewTrade(0, 0, time.Time{}, "", order.id, "", order.traderID, order.side.Opposite(), order.amount.Add(order.hiddenAmount), order.price)
+	if fee, _, feeCurrency := m.book.fees.Fees(m.book.symbol, trade); feeCurrency == currency {
+		return fee
+	}
+
+	return decimal.Zero
+}
+
+// parties returns the order and trader of the buyer and of the seller of a trade.
+func (m *MarketLedger) parties(trade *Trade) (string, string, string, string) {
+	if trade.side == Sell {
+		return trade.makerOrderID, trade.makerTraderID, trade.takerOrderID, trade.takerTraderID
+	}
+
+	return trade.takerOrderID, trade.takerTraderID, trade.makerOrderID, trade.makerTraderID
+}
+
+// settle moves the funds of both sides of a trade, taking them from the holds first, and charges the fees.
+func (m *MarketLedger) settle(trade *Trade) {
+	buyerOrderID, buyerID, sellerOrderID, sellerID := m.parties(trade)
+
+	notional := trade.amount.Mul(trade.price)
+
+	m.pay(buyerOrderID, buyerID, m.quote, notional)
+	m.pay(sellerOrderID, sellerID, m.base, trade.amount)
+
+	m.credit(buyerID, m.base, trade.amount)
+	m.credit(sellerID, m.quote, notional)
+
+	if trade.feeCurrency != "" {
+		m.charge(trade.makerOrderID, trade.makerTraderID, trade.feeCurrency, trade.makerFee)
+		m.charge(trade.takerOrderID, trade.takerTraderID, trade.feeCurrency, trade.takerFee)
+	}
+}
+
+// charge takes a fee from the available funds first and what is missing from the hold of the order. A negative fee is a rebate.
+func (m *MarketLedger) charge(orderID, traderID, currency string, fee decimal.Decimal) {
+	if !fee.IsPositive() {
+		m.credit(traderID, currency, fee.Neg())
+		return
+	}
+
+	b := m.ledger.account(traderID, currency)
+	available := decimal.Max(decimal.Min(b.available, fee), decimal.Zero)
+	b.available = b.available.Sub(available)
+	m.pay(orderID, traderID, currency, fee.Sub(available))
+}
+
+func (m *MarketLedger) pay(orderID, traderID, currency string, amount decimal.Decimal) {
+	b := m.ledger.account(traderID, currency)
+
+	if h, ok := m.holds[orderID]; ok && h.currency == currency {
+		held := decimal.Min(h.amount, amount)
+		h.amount = h.amount.Sub(held)
+		b.held = b.held.Sub(held)
+		amount = amount.Sub(held)
+	}
+
+	b.available = b.available.Sub(amount)
+}
+
+func (m *MarketLedger) credit(traderID, currency string, amount decimal.Decimal) {
+	b := m.ledger.account(traderID, currency)
+	b.available = b.available.Add(amount)
+}
+
+// reserve moves an amount from available to held funds, or back when it is negative.
+func (m *MarketLedger) reserve(h *hold, amount decimal.Decimal) {
+	b := m.ledger.account(h.traderID, h.currency)
+	b.available = b.available.Sub(amount)
+	b.held = b.held.Add(amount)
+	h.amount = h.amount.Add(amount)
+}
+
+// release frees the funds left in the hold of an order.
+func (m *MarketLedger) release(orderID string) {
+	h, ok := m.holds[orderID]
+	if !ok {
+		return
+	}
+
+	m.reserve(h, h.amount.Neg())
+	delete(m.holds, orderID)
+}
+
+// walk returns the amount and notional an order for the amount, or the funds, takes from the levels, best first.
+func walk(levels []*PriceLevel, amount, funds decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	taken, notional := decimal.Zero, decimal.Zero
+
+	for _, level := range levels {
+		take := level.amount
+		if funds.IsPositive() {
+			take = decimal.Min(take, funds.Sub(notional).Div(level.price))
+		} else {
+			take = decimal.Min(take, amount.Sub(taken))
+		}
+
+		if take.LessThanOrEqual(decimal.Zero) {
+			break
+		}
+
+		taken = taken.Add(take)
+		notional = notional.Add(take.Mul(level.price))
+	}
+
+	return taken, notional
+}
@@ -0,0 +1,277 @@
+package orderbook_test
+
+import (
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger(t *testing.T) {
+	ledger := orderbook.NewLedger()
+
+	assert.Nil(t, ledger.Deposit("1", "BTC", decimal.NewFromInt(10)))
+	assert.Nil(t, ledger.Deposit("2", "USD", decimal.NewFromInt(1000)))
+	assert.Equal(t, orderbook.ErrInvalidAmount, ledger.Deposit("1", "BTC", decimal.Zero))
+	assert.Equal(t, orderbook.ErrInsufficientFunds, ledger.Withdraw("1", "BTC", decimal.NewFromInt(11)))
+	assert.Nil(t, ledger.Withdraw("1", "BTC", decimal.NewFromInt(5)))
+	assert.Equal(t, "5", ledger.Balance("1", "BTC").Available().String())
+	assert.Len(t, ledger.Balances("1"), 1)
+}
+
+func TestMarketLedger(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market))
+
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(5)))
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(1000)))
+
+	balance := func(traderID, currency string) (string, string) {
+		b := ledger.Balance(traderID, currency)
+		return b.Available().String(), b.Held().String()
+	}
+
+	_, err := book.ProcessLimitOrder("1", "seller", orderbook.Sell, decimal.NewFromInt(6), decimal.NewFromInt(100))
+	assert.Equal(t, orderbook.ErrInsufficientFunds, err)
+
+	available, held := balance("seller", "BTC")
+	assert.Equal(t, "5", available)
+	assert.Equal(t, "0", held)
+
+	_, err = book.ProcessLimitOrder("1", "seller", orderbook.Sell, decimal.NewFromInt(4), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, "4", market.Held("1").String())
+
+	available, held = balance("seller", "BTC")
+	assert.Equal(t, "1", available)
+	assert.Equal(t, "4", held)
+
+	trades, err := book.ProcessLimitOrder("2", "buyer", orderbook.Buy, decimal.NewFromInt(3), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+	assert.True(t, market.Held("2").IsZero())
+
+	available, held = balance("buyer", "USD")
+	assert.Equal(t, "700", available)
+	assert.Equal(t, "0", held)
+
+	available, held = balance("buyer", "BTC")
+	assert.Equal(t, "3", available)
+	assert.Equal(t, "0", held)
+
+	available, held = balance("seller", "USD")
+	assert.Equal(t, "300", available)
+	assert.Equal(t, "0", held)
+
+	available, held = balance("seller", "BTC")
+	assert.Equal(t, "1", available)
+	assert.Equal(t, "1", held)
+
+	_, err = book.ProcessLimitOrder("3", "buyer", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	available, held = balance("buyer", "USD")
+	assert.Equal(t, "520", available)
+	assert.Equal(t, "180", held)
+
+	_, err = book.ProcessMarketOrderByFunds("4", "buyer", orderbook.Buy, decimal.NewFromInt(600))
+	assert.Equal(t, orderbook.ErrInsufficientFunds, err)
+
+	execution, err := book.ProcessMarketOrderByFunds("4", "buyer", orderbook.Buy, decimal.NewFromInt(500))
+	assert.Nil(t, err)
+	assert.Equal(t, "100", execution.Notional().String())
+
+	available, held = balance("buyer", "USD")
+	assert.Equal(t, "420", available)
+	assert.Equal(t, "180", held)
+
+	assert.NotNil(t, book.CancelOrder("3"))
+
+	available, held = balance("buyer", "USD")
+	assert.Equal(t, "600", available)
+	assert.Equal(t, "0", held)
+
+	available, held = balance("seller", "BTC")
+	assert.Equal(t, "1", available)
+	assert.Equal(t, "0", held)
+}
+
+func TestMarketLedgerFees(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	fees := orderbook.NewTieredFeeSchedule("USD", orderbook.NewFeeTier(decimal.RequireFromString("-0.001"), decimal.RequireFromString("0.002")))
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market), orderbook.WithFeeSchedule(fees))
+
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(1)))
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(1010)))
+
+	_, err := book.ProcessLimitOrder("1", "seller", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(1000))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessMarketOrder("2", "buyer", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "1001", ledger.Balance("seller", "USD").Available().String())
+	assert.Equal(t, "8", ledger.Balance("buyer", "USD").Available().String())
+	assert.Equal(t, "1", ledger.Balance("buyer", "BTC").Available().String())
+}
+
+func TestMarketLedgerIcebergShortfall(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market))
+
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(10)))
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(150)))
+
+	_, err := book.ProcessLimitOrder("1", "seller", orderbook.Sell, decimal.NewFromInt(10), decimal.NewFromInt(100), orderbook.WithDisplayAmount(decimal.NewFromInt(1)))
+	assert.Nil(t, err)
+
+	execution, err := book.ProcessMarketOrder("2", "buyer", orderbook.Buy, decimal.NewFromInt(2))
+	assert.Equal(t, orderbook.ErrInsufficientFunds, err)
+	assert.Equal(t, "1", execution.Amount().String())
+
+	assert.Equal(t, "50", ledger.Balance("buyer", "USD").Available().String())
+	assert.Equal(t, "0", ledger.Balance("buyer", "USD").Held().String())
+	assert.Equal(t, "1", ledger.Balance("buyer", "BTC").Available().String())
+	assert.Equal(t, "9", ledger.Balance("seller", "BTC").Held().String())
+}
+
+func TestMarketLedgerStopShortfall(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market))
+
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(2)))
+	assert.Nil(t, ledger.Deposit("other", "USD", decimal.NewFromInt(100)))
+
+	rejected := make([]error, 0)
+	book.Subscribe(orderbook.ListenerFunc(func(event *orderbook.Event) {
+		if event.Type() == orderbook.OrderRejected {
+			rejected = append(rejected, event.Err())
+		}
+	}))
+
+	_, err := book.ProcessStopOrder("1", "buyer", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "seller", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("3", "other", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, []error{orderbook.ErrInsufficientFunds}, rejected)
+
+	assert.Equal(t, "0", ledger.Balance("buyer", "USD").Available().String())
+	assert.Equal(t, "0", ledger.Balance("buyer", "BTC").Available().String())
+	assert.Equal(t, "1", ledger.Balance("seller", "BTC").Held().String())
+}
+
+func TestMarketLedgerFeeShortfall(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	fees := orderbook.NewTieredFeeSchedule("USD", orderbook.NewFeeTier(decimal.RequireFromString("0.001"), decimal.RequireFromString("0.002")))
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market), orderbook.WithFeeSchedule(fees))
+
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(1)))
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(1000)))
+
+	_, err := book.ProcessLimitOrder("1", "buyer", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1000))
+	assert.Nil(t, err)
+
+	execution, err := book.ProcessMarketOrder("2", "seller", orderbook.Sell, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 0)
+	assert.Empty(t, book.OpenOrders("buyer"))
+
+	assert.Equal(t, "1000", ledger.Balance("buyer", "USD").Available().String())
+	assert.Equal(t, "0", ledger.Balance("buyer", "USD").Held().String())
+	assert.Equal(t, "1", ledger.Balance("seller", "BTC").Available().String())
+}
+
+func TestMarketLedgerAmend(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market))
+
+	var amended *orderbook.Order
+	book.Subscribe(orderbook.ListenerFunc(func(event *orderbook.Event) {
+		if event.Type() == orderbook.OrderAmended {
+			amended = event.Order()
+		}
+	}))
+
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(1000)))
+
+	_, err := book.ProcessLimitOrder("1", "buyer", orderbook.Buy, decimal.NewFromInt(5), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, "500", market.Held("1").String())
+
+	_, err = book.AmendOrder("1", decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, "2", amended.Amount().String())
+
+	assert.Equal(t, "200", market.Held("1").String())
+	assert.Equal(t, "800", ledger.Balance("buyer", "USD").Available().String())
+	assert.Equal(t, "200", ledger.Balance("buyer", "USD").Held().String())
+}
+
+func TestMarketLedgerAuctionShortfall(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	fees := orderbook.NewTieredBaseFeeSchedule("BTC", orderbook.NewFeeTier(decimal.RequireFromString("0.001"), decimal.Zero))
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market), orderbook.WithFeeSchedule(fees))
+
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(1)))
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(100)))
+	assert.Nil(t, book.StartAuction())
+
+	_, err := book.ProcessLimitOrder("1", "seller", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "buyer", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	trades, err := book.Uncross()
+	assert.Nil(t, err)
+	assert.Len(t, trades, 0)
+	assert.Empty(t, book.OpenOrders("seller"))
+	assert.Len(t, book.OpenOrders("buyer"), 1)
+
+	assert.Equal(t, "1", ledger.Balance("seller", "BTC").Available().String())
+	assert.Equal(t, "100", ledger.Balance("buyer", "USD").Held().String())
+}
+
+func TestMarketLedgerMakerShortfall(t *testing.T) {
+	ledger := orderbook.NewLedger()
+	market := ledger.Market("BTC", "USD")
+	fees := orderbook.NewTieredBaseFeeSchedule("BTC", orderbook.NewFeeTier(decimal.RequireFromString("0.001"), decimal.Zero))
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithLedger(market), orderbook.WithFeeSchedule(fees))
+
+	assert.Nil(t, ledger.Deposit("short", "BTC", decimal.NewFromInt(1)))
+	assert.Nil(t, ledger.Deposit("seller", "BTC", decimal.NewFromInt(2)))
+	assert.Nil(t, ledger.Deposit("buyer", "USD", decimal.NewFromInt(200)))
+
+	_, err := book.ProcessLimitOrder("1", "short", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, "1", market.Held("1").String())
+
+	_, err = book.ProcessLimitOrder("2", "seller", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, "1.001", market.Held("2").String())
+
+	trades, err := book.ProcessLimitOrder("3", "buyer", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, "2", trades[0].MakerOrderID())
+	assert.Empty(t, book.OpenOrders("short"))
+
+	assert.Equal(t, "1", ledger.Balance("short", "BTC").Available().String())
+	assert.Equal(t, "0", ledger.Balance("short", "BTC").Held().String())
+	assert.Equal(t, "0.999", ledger.Balance("seller", "BTC").Available().String())
+	assert.Equal(t, "0", ledger.Balance("seller", "BTC").Held().String())
+	assert.Equal(t, "1", ledger.Balance("buyer", "BTC").Available().String())
+}
@@ -0,0 +1,188 @@
+package orderbook
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Option configures an order book.
+type Option func(*OrderBook)
+
+// WithClock sets the clock used to timestamp and expire orders. Defaults to time.Now.
+func WithClock(clock func() time.Time) Option {
+	return func(ob *OrderBook) {
+		ob.clock = clock
+	}
+}
+
+// WithDefaultSelfTradePrevention sets the self trade prevention mode of orders without one. Defaults to STPSkip.
+func WithDefaultSelfTradePrevention(mode SelfTradePrevention) Option {
+	return func(ob *OrderBook) {
+		ob.stp = mode
+	}
+}
+
+// WithCancelHandler sets a handler called with every order, or unfilled amount of a taker, cancelled by the book itself.
+func WithCancelHandler(handler func(order *Order)) Option {
+	return func(ob *OrderBook) {
+		ob.onCancel = handler
+	}
+}
+
+// WithInstrument sets the trading rules every order must conform to.
+func WithInstrument(instrument *Instrument) Option {
+	return func(ob *OrderBook) {
+		ob.instrument = instrument
+	}
+}
+
+// WithTraderTags sets the function turning a trader ID into the anonymized tag of the orders in OrderDepth and the order feed.
+// Orders are not tagged by default.
+func WithTraderTags(tag func(traderID string) string) Option {
+	return func(ob *OrderBook) {
+		ob.traderTag = tag
+	}
+}
+
+// WithDenseLevels keeps the price levels from min to max price in arrays indexed by tick offset instead of trees,
+// for instruments with a known tick size and a narrow band. Prices off the tick grid or out of the band still work, in a tree.
+// It fails like NewDenseOrderSide.
+func WithDenseLevels(tickSize, minPrice, maxPrice decimal.Decimal) (Option, error) {
+	if _, err := denseSize(tickSize, minPrice, maxPrice); err != nil {
+		return nil, err
+	}
+
+	return func(ob *OrderBook) {
+		ob.newSide = func(side Side) *OrderSide {
+			os, _ := NewDenseOrderSide(side, tickSize, minPrice, maxPrice)
+			return os
+		}
+	}, nil
+}
+
+// WithPriceBand sets the circuit breaker checked by the matching of limit and market orders.
+func WithPriceBand(band *PriceBand) Option {
+	return func(ob *OrderBook) {
+		ob.band = band
+	}
+}
+
+// WithFeeSchedule sets the fee schedule charging every trade.
+func WithFeeSchedule(fees FeeSchedule) Option {
+	return func(ob *OrderBook) {
+		ob.fees = fees
+	}
+}
+
+// WithRiskChecks sets the checks every new or amended order must pass before matching.
+func WithRiskChecks(checks ...RiskCheck) Option {
+	return func(ob *OrderBook) {
+		ob.riskChecks = append(ob.riskChecks, checks...)
+	}
+}
+
+// WithLedger holds the funds of the orders in the ledger and settles the trades.
+func WithLedger(ledger *MarketLedger) Option {
+	return func(ob *OrderBook) {
+		ledger.book = ob
+		ob.riskChecks = append(ob.riskChecks, ledger)
+		ob.listeners = append(ob.listeners, ledger)
+	}
+}
+
+// OrderOption configures an order.
+type OrderOption func(*orderOptions)
+
+type orderOptions struct {
+	timeInForce   TimeInForce
+	expireAt      time.Time
+	displayAmount decimal.Decimal
+
+	selfTradePrevention *SelfTradePrevention
+}
+
+// WithTimeInForce sets the time in force. Defaults to GTC.
+func WithTimeInForce(timeInForce TimeInForce) OrderOption {
+	return func(o *orderOptions) {
+		o.timeInForce = timeInForce
+	}
+}
+
+// WithExpireTime sets the expire time of a GTD order.
+func WithExpireTime(expireAt time.Time) OrderOption {
+	return func(o *orderOptions) {
+		o.expireAt = expireAt
+	}
+}
+
+// WithDisplayAmount makes the order an iceberg order showing at most the display amount.
+// The hidden reserve refills the visible amount, at the back of the queue, each time it is consumed.
+func WithDisplayAmount(displayAmount decimal.Decimal) OrderOption {
+	return func(o *orderOptions) {
+		o.displayAmount = displayAmount
+	}
+}
+
+// WithSelfTradePrevention sets the self trade prevention mode of the order, overriding the book default.
+func WithSelfTradePrevention(mode SelfTradePrevention) OrderOption {
+	return func(o *orderOptions) {
+		o.selfTradePrevention = &mode
+	}
+}
+
+func newOrderOptions(opts []OrderOption) orderOptions {
+	var o orderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// DepthOption configures a depth.
+type DepthOption func(*depthOptions)
+
+type depthOptions struct {
+	limit      int
+	grouping   decimal.Decimal
+	counts     bool
+	cumulative bool
+}
+
+// WithDepthLimit keeps the best levels of each side only. Zero keeps them all.
+func WithDepthLimit(levels int) DepthOption {
+	return func(o *depthOptions) {
+		o.limit = levels
+	}
+}
+
+// WithDepthGrouping aggregates the prices into buckets of the step, rounding bids down and asks up.
+func WithDepthGrouping(step decimal.Decimal) DepthOption {
+	return func(o *depthOptions) {
+		o.grouping = step
+	}
+}
+
+// WithOrderCounts counts the orders of each level.
+func WithOrderCounts() DepthOption {
+	return func(o *depthOptions) {
+		o.counts = true
+	}
+}
+
+// WithCumulative sums the amount and notional of each level and the better ones.
+func WithCumulative() DepthOption {
+	return func(o *depthOptions) {
+		o.cumulative = true
+	}
+}
+
+func newDepthOptions(opts []DepthOption) depthOptions {
+	var o depthOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -3,6 +3,7 @@ package orderbook
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -17,11 +18,19 @@ type Order struct {
 	side     Side
 	amount   decimal.Decimal
 	price    decimal.Decimal
+	expireAt time.Time
+
+	displayAmount decimal.Decimal
+	hiddenAmount  decimal.Decimal
+
+	funds decimal.Decimal
+
+	selfTradePrevention *SelfTradePrevention
 }
 
 // NewOrder creates a new order.
 func NewOrder(ID, traderID string, side Side, amount, price decimal.Decimal) *Order {
-	return &Order{ID, traderID, side, amount, price}
+	return &Order{ID, traderID, side, amount, price, time.Time{}, decimal.Zero, decimal.Zero, decimal.Zero, nil}
 }
 
 // ID returns the order ID.
@@ -49,21 +58,93 @@ func (o *Order) Price() decimal.Decimal {
 	return o.price
 }
 
+// ExpireAt returns the expire time of a GTD order or the zero time when the order does not expire.
+func (o *Order) ExpireAt() time.Time {
+	return o.expireAt
+}
+
+// DisplayAmount returns the peak shown by an iceberg order or zero when the order is fully visible.
+func (o *Order) DisplayAmount() decimal.Decimal {
+	return o.displayAmount
+}
+
+// HiddenAmount returns the hidden reserve of an iceberg order.
+func (o *Order) HiddenAmount() decimal.Decimal {
+	return o.hiddenAmount
+}
+
+// Funds returns the quote amount a market order by funds spends, or receives when selling, or zero for any other order.
+func (o *Order) Funds() decimal.Decimal {
+	return o.funds
+}
+
+// SelfTradePrevention returns the self trade prevention mode the order was accepted with, or false when it uses the book default.
+func (o *Order) SelfTradePrevention() (SelfTradePrevention, bool) {
+	if o.selfTradePrevention == nil {
+		return STPSkip, false
+	}
+
+	return *o.selfTradePrevention, true
+}
+
+func (o *Order) clone() *Order {
+	c := *o
+	return &c
+}
+
+// replenish refills the visible amount from the hidden reserve.
+func (o *Order) replenish() {
+	o.amount = decimal.Min(o.displayAmount, o.hiddenAmount)
+	o.hiddenAmount = o.hiddenAmount.Sub(o.amount)
+}
+
+// expired returns true when the order has an expire time not after now.
+func (o *Order) expired(now time.Time) bool {
+	return !o.expireAt.IsZero() && !now.Before(o.expireAt)
+}
+
 // MarshalJSON implements json.Marshaler.
 func (o *Order) MarshalJSON() ([]byte, error) {
+	var expireAt *time.Time
+	if !o.expireAt.IsZero() {
+		expireAt = &o.expireAt
+	}
+
+	var displayAmount, hiddenAmount *decimal.Decimal
+	if !o.displayAmount.IsZero() {
+		displayAmount = &o.displayAmount
+		hiddenAmount = &o.hiddenAmount
+	}
+
+	var funds *decimal.Decimal
+	if !o.funds.IsZero() {
+		funds = &o.funds
+	}
+
 	return json.Marshal(
 		&struct {
-			ID       string          `json:"id"`
-			TraderID string          `json:"traderId"`
-			Side     Side            `json:"side"`
-			Amount   decimal.Decimal `json:"amount"`
-			Price    decimal.Decimal `json:"price"`
+			ID            string           `json:"id"`
+			TraderID      string           `json:"traderId"`
+			Side          Side             `json:"side"`
+			Amount        decimal.Decimal  `json:"amount"`
+			Price         decimal.Decimal  `json:"price"`
+			ExpireAt      *time.Time       `json:"expireAt,omitempty"`
+			DisplayAmount *decimal.Decimal `json:"displayAmount,omitempty"`
+			HiddenAmount  *decimal.Decimal `json:"hiddenAmount,omitempty"`
+			Funds         *decimal.Decimal `json:"funds,omitempty"`
+
+			SelfTradePrevention *SelfTradePrevention `json:"selfTradePrevention,omitempty"`
 		}{
 			o.id,
 			o.traderID,
 			o.side,
 			o.amount,
 			o.price,
+			expireAt,
+			displayAmount,
+			hiddenAmount,
+			funds,
+			o.selfTradePrevention,
 		},
 	)
 }
@@ -71,11 +152,17 @@ func (o *Order) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements json.Unmarshaler.
 func (o *Order) UnmarshalJSON(data []byte) error {
 	obj := struct {
-		ID       string          `json:"id"`
-		TraderID string          `json:"traderId"`
-		Side     Side            `json:"side"`
-		Amount   decimal.Decimal `json:"amount"`
-		Price    decimal.Decimal `json:"price"`
+		ID            string          `json:"id"`
+		TraderID      string          `json:"traderId"`
+		Side          Side            `json:"side"`
+		Amount        decimal.Decimal `json:"amount"`
+		Price         decimal.Decimal `json:"price"`
+		ExpireAt      time.Time       `json:"expireAt"`
+		DisplayAmount decimal.Decimal `json:"displayAmount"`
+		HiddenAmount  decimal.Decimal `json:"hiddenAmount"`
+		Funds         decimal.Decimal `json:"funds"`
+
+		SelfTradePrevention *SelfTradePrevention `json:"selfTradePrevention"`
 	}{}
 
 	if err := json.Unmarshal(data, &obj); err != nil {
@@ -88,6 +175,11 @@ func (o *Order) UnmarshalJSON(data []byte) error {
 	o.side = obj.Side
 	o.amount = obj.Amount
 	o.price = obj.Price
+	o.expireAt = obj.ExpireAt
+	o.displayAmount = obj.DisplayAmount
+	o.hiddenAmount = obj.HiddenAmount
+	o.funds = obj.Funds
+	o.selfTradePrevention = obj.SelfTradePrevention
 
 	return nil
 }
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 var _ json.Marshaler = (*OrderBook)(nil)
@@ -13,16 +16,64 @@ var _ json.Unmarshaler = (*OrderBook)(nil)
 // OrderBook represents a order book for a given market symbol.
 type OrderBook struct {
 	sync.RWMutex
-	symbol  string
-	version uint64
-	orders  map[string]*list.Element
-	asks    *OrderSide
-	bids    *OrderSide
+	symbol    string
+	version   uint64
+	tradeID   uint64
+	lastPrice decimal.Decimal
+	orders    map[string]*list.Element
+	asks      *OrderSide
+	bids      *OrderSide
+	stops     map[string]*list.Element
+	stopBuys  *StopSide
+	stopSells *StopSide
+	clock     func() time.Time
+	stp       SelfTradePrevention
+	onCancel  func(*Order)
+	listeners []Listener
+
+	depthListeners []DepthListener
+	orderListeners []OrderListener
+	traderTag      func(traderID string) string
+	executing      bool
+
+	journal   *json.Encoder
+	replaying bool
+	recorded  bool
+
+	instrument *Instrument
+	status     TradingStatus
+
+	band     *PriceBand
+	resumeAt time.Time
+
+	fees FeeSchedule
+
+	riskChecks []RiskCheck
+	traders    map[string]int
+
+	newSide func(side Side) *OrderSide
 }
 
 // NewOrderBook creates a new order book.
-func NewOrderBook(symbol string) *OrderBook {
-	return &OrderBook{sync.RWMutex{}, symbol, 0, make(map[string]*list.Element), NewOrderSide(Sell), NewOrderSide(Buy)}
+func NewOrderBook(symbol string, opts ...Option) *OrderBook {
+	ob := &OrderBook{
+		symbol:    symbol,
+		orders:    make(map[string]*list.Element),
+		stops:     make(map[string]*list.Element),
+		stopBuys:  NewStopSide(Buy),
+		stopSells: NewStopSide(Sell),
+		traders:   make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(ob)
+	}
+
+	ob.asks = ob.orderSide(Sell)
+	ob.bids = ob.orderSide(Buy)
+
+	ob.watch()
+	return ob
 }
 
 // Symbol returns the symbol.
@@ -35,44 +86,170 @@ func (ob *OrderBook) Version() uint64 {
 	return ob.version
 }
 
+// Instrument returns the trading rules or nil when there are none.
+func (ob *OrderBook) Instrument() *Instrument {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.instrument
+}
+
+// SetInstrument sets the trading rules every new order must conform to. Resting orders are kept as they are.
+// The rules are kept when the journal can not record the change.
+func (ob *OrderBook) SetInstrument(instrument *Instrument) error {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	if err := ob.record(&Command{commandType: InstrumentCommand, instrument: instrument}); err != nil {
+		return err
+	}
+
+	ob.instrument = instrument
+	return nil
+}
+
+// OpenOrders returns a copy of the resting and stop orders of a trader, bids then asks then stops.
+func (ob *OrderBook) OpenOrders(traderID string) []*Order {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	orders := make([]*Order, 0, ob.traders[traderID])
+	if ob.traders[traderID] == 0 {
+		return orders
+	}
+
+	for _, order := range append(ob.bids.Orders(), ob.asks.Orders()...) {
+		if order.traderID == traderID {
+			orders = append(orders, order.clone())
+		}
+	}
+
+	for _, stop := range append(ob.stopBuys.Orders(), ob.stopSells.Orders()...) {
+		if stop.order.traderID == traderID {
+			orders = append(orders, stop.order.clone())
+		}
+	}
+
+	return orders
+}
+
+// LastTradeID returns the ID of the last trade or zero when nothing was traded yet.
+func (ob *OrderBook) LastTradeID() uint64 {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.tradeID
+}
+
+// LastPrice returns the price of the last trade or zero when nothing was traded yet.
+func (ob *OrderBook) LastPrice() decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.lastPrice
+}
+
+func (ob *OrderBook) orderSide(side Side) *OrderSide {
+	if ob.newSide == nil {
+		return NewOrderSide(side)
+	}
+
+	return ob.newSide(side)
+}
+
+func (ob *OrderBook) now() time.Time {
+	if ob.clock == nil {
+		return time.Now()
+	}
+
+	return ob.clock()
+}
+
 // Reset resets the order book.
 func (ob *OrderBook) Reset(version uint64) {
 	defer ob.Unlock()
 	ob.Lock()
 
 	ob.orders = make(map[string]*list.Element)
-	ob.asks = NewOrderSide(Sell)
-	ob.bids = NewOrderSide(Buy)
+	ob.asks = ob.orderSide(Sell)
+	ob.bids = ob.orderSide(Buy)
+	ob.stops = make(map[string]*list.Element)
+	ob.stopBuys = NewStopSide(Buy)
+	ob.stopSells = NewStopSide(Sell)
+	ob.traders = make(map[string]int)
+	ob.lastPrice = decimal.Zero
+	ob.status = Open
+	ob.resumeAt = time.Time{}
 	ob.version = version
+	ob.watch()
 }
 
 // MarshalJSON implements json.MarshalJSON.
 func (ob *OrderBook) MarshalJSON() ([]byte, error) {
+	var lastPrice *decimal.Decimal
+	if !ob.lastPrice.IsZero() {
+		lastPrice = &ob.lastPrice
+	}
+
+	var resumeAt *time.Time
+	if !ob.resumeAt.IsZero() {
+		resumeAt = &ob.resumeAt
+	}
+
+	var stops []*StopOrder
+	if ob.stopBuys != nil && ob.stopSells != nil {
+		stops = append(ob.stopBuys.Orders(), ob.stopSells.Orders()...)
+	}
+
 	return json.Marshal(
 		&struct {
-			Symbol  string   `json:"symbol"`
-			Bids    []*Order `json:"bids"`
-			Asks    []*Order `json:"asks"`
-			Version uint64   `json:"version"`
+			Symbol    string           `json:"symbol"`
+			Bids      []*Order         `json:"bids"`
+			Asks      []*Order         `json:"asks"`
+			Stops     []*StopOrder     `json:"stops,omitempty"`
+			LastPrice *decimal.Decimal `json:"lastPrice,omitempty"`
+			TradeID   uint64           `json:"lastTradeId,omitempty"`
+			Status    TradingStatus    `json:"status,omitempty"`
+			ResumeAt  *time.Time       `json:"resumeAt,omitempty"`
+			Version   uint64           `json:"version"`
+
+			Instrument *Instrument `json:"instrument,omitempty"`
 		}{
 			ob.symbol,
 			ob.bids.Orders(),
 			ob.asks.Orders(),
+			stops,
+			lastPrice,
+			ob.tradeID,
+			ob.status,
+			resumeAt,
 			ob.version,
+			ob.instrument,
 		},
 	)
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
+// UnmarshalJSON implements json.Unmarshaler. The instrument of the snapshot replaces the configured one, kept when there is none.
 func (ob *OrderBook) UnmarshalJSON(data []byte) error {
 	defer ob.Unlock()
 	ob.Lock()
 
 	obj := struct {
-		Symbol  string   `json:"symbol"`
-		Bids    []*Order `json:"bids"`
-		Asks    []*Order `json:"asks"`
-		Version uint64   `json:"version"`
+		Symbol    string          `json:"symbol"`
+		Bids      []*Order        `json:"bids"`
+		Asks      []*Order        `json:"asks"`
+		Stops     []*StopOrder    `json:"stops"`
+		LastPrice decimal.Decimal `json:"lastPrice"`
+		TradeID   uint64          `json:"lastTradeId"`
+		Status    TradingStatus   `json:"status"`
+		ResumeAt  time.Time       `json:"resumeAt"`
+		Version   uint64          `json:"version"`
+
+		Instrument *Instrument `json:"instrument"`
 	}{}
 
 	if err := json.Unmarshal(data, &obj); err != nil {
@@ -81,17 +258,62 @@ func (ob *OrderBook) UnmarshalJSON(data []byte) error {
 
 	ob.symbol = obj.Symbol
 	ob.version = obj.Version
+	ob.lastPrice = obj.LastPrice
+	ob.tradeID = obj.TradeID
+	ob.status = obj.Status
+	ob.resumeAt = obj.ResumeAt
 	ob.orders = make(map[string]*list.Element)
 
-	ob.asks = NewOrderSide(Sell)
+	if obj.Instrument != nil {
+		ob.instrument = obj.Instrument
+	}
+	ob.traders = make(map[string]int)
+
+	ob.asks = ob.orderSide(Sell)
 	for _, order := range obj.Asks {
 		ob.orders[order.id] = ob.asks.Append(order)
+		ob.traders[order.traderID]++
 	}
 
-	ob.bids = NewOrderSide(Buy)
+	ob.bids = ob.orderSide(Buy)
 	for _, order := range obj.Bids {
 		ob.orders[order.id] = ob.bids.Append(order)
+		ob.traders[order.traderID]++
+	}
+
+	ob.stops = make(map[string]*list.Element)
+	ob.stopBuys = NewStopSide(Buy)
+	ob.stopSells = NewStopSide(Sell)
+	for _, stop := range obj.Stops {
+		if stop.order.side == Buy {
+			ob.stops[stop.order.id] = ob.stopBuys.Append(stop)
+		} else {
+			ob.stops[stop.order.id] = ob.stopSells.Append(stop)
+		}
+
+		ob.traders[stop.order.traderID]++
 	}
 
+	ob.watch()
 	return nil
 }
+
+// fill removes a fully matched maker order, or replenishes it when it still has a hidden reserve, and returns the next order to match.
+func (ob *OrderBook) fill(e *list.Element) *list.Element {
+	order := e.Value.(*Order)
+	next := e.Next()
+
+	if order.hiddenAmount.IsZero() {
+		ob.remove(order.id)
+		return next
+	}
+
+	e = ob.sideOf(order).Replenish(e)
+	ob.orders[order.id] = e
+
+	if next == nil {
+		return e
+	}
+
+	return next
+}
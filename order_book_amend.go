@@ -0,0 +1,79 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// AmendOrder amends the amount and price of a resting order.
+// Reducing the amount at the same price keeps the queue position and emits OrderAmended.
+// Increasing the amount or changing the price loses it, the order is matched again as a taker, with the self trade prevention
+// mode it was accepted with, and the unfilled amount rests at the back of the queue.
+func (ob *OrderBook) AmendOrder(orderID string, amount, price decimal.Decimal) (trades []*Trade, err error) {
+	defer func() {
+		if err != nil {
+			ob.emit(OrderRejected, &Order{id: orderID, amount: amount, price: price}, nil, err)
+		}
+
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	if err := ob.allow(AmendCommand); err != nil {
+		return nil, err
+	}
+
+	e, ok := ob.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	if price.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidPrice
+	}
+
+	if err := ob.instrument.Validate(amount, price); err != nil {
+		return nil, err
+	}
+
+	order := e.Value.(*Order)
+
+	if err := ob.check(NewOrder(order.id, order.traderID, order.side, amount, price)); err != nil {
+		return nil, err
+	}
+
+	if err := ob.record(&Command{commandType: AmendCommand, orderID: orderID, amount: amount, price: price}); err != nil {
+		return nil, err
+	}
+
+	if order.price.Equal(price) && amount.LessThanOrEqual(order.amount.Add(order.hiddenAmount)) {
+		if amount.LessThan(order.amount) {
+			order.hiddenAmount = decimal.Zero
+			ob.sideOf(order).UpdateAmount(e, amount)
+		} else {
+			order.hiddenAmount = amount.Sub(order.amount)
+		}
+
+		ob.emit(OrderAmended, order.clone(), nil, nil)
+		return make([]*Trade, 0), nil
+	}
+
+	ob.remove(orderID)
+
+	var o orderOptions
+	if !order.expireAt.IsZero() {
+		o.timeInForce = GTD
+		o.expireAt = order.expireAt
+	}
+
+	o.displayAmount = order.displayAmount
+	o.selfTradePrevention = order.selfTradePrevention
+
+	trades, err = ob.processLimit(order.id, order.traderID, order.side, amount, price, o)
+	return ob.triggerStopOrders(trades), err
+}
@@ -0,0 +1,176 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmendOrder(t *testing.T) {
+	type input struct {
+		OrderID string
+		amount  decimal.Decimal
+		price   decimal.Decimal
+	}
+
+	type snapshot struct {
+		Book   *orderbook.OrderBook
+		Trades []*orderbook.Trade
+		Err    string
+	}
+
+	tests := []struct {
+		name  string
+		input input
+	}{
+		{
+			name: "reduce amount keeps priority",
+			input: input{
+				OrderID: "1",
+				amount:  decimal.NewFromInt(1),
+				price:   decimal.NewFromInt(300),
+			},
+		},
+		{
+			name: "increase amount loses priority",
+			input: input{
+				OrderID: "1",
+				amount:  decimal.NewFromInt(3),
+				price:   decimal.NewFromInt(300),
+			},
+		},
+		{
+			name: "change price",
+			input: input{
+				OrderID: "1",
+				amount:  decimal.NewFromInt(2),
+				price:   decimal.NewFromInt(350),
+			},
+		},
+		{
+			name: "change price crossing",
+			input: input{
+				OrderID: "4",
+				amount:  decimal.NewFromInt(3),
+				price:   decimal.NewFromInt(300),
+			},
+		},
+		{
+			name: "not found",
+			input: input{
+				OrderID: "foo",
+				amount:  decimal.NewFromInt(1),
+				price:   decimal.NewFromInt(300),
+			},
+		},
+		{
+			name: "invalid amount",
+			input: input{
+				OrderID: "1",
+				amount:  decimal.NewFromInt(0),
+				price:   decimal.NewFromInt(300),
+			},
+		},
+		{
+			name: "invalid price",
+			input: input{
+				OrderID: "1",
+				amount:  decimal.NewFromInt(1),
+				price:   decimal.NewFromInt(0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given := []byte(`
+				{
+					"bids": [
+						{
+							"id": "1",
+							"traderId": "1",
+							"side": "buy",
+							"amount": "2",
+							"price": "300"
+						},
+						{
+							"id": "2",
+							"traderId": "2",
+							"side": "buy",
+							"amount": "2",
+							"price": "300"
+						}
+					],
+					"asks": [
+						{
+							"id": "3",
+							"traderId": "3",
+							"side": "sell",
+							"amount": "1",
+							"price": "400"
+						},
+						{
+							"id": "4",
+							"traderId": "4",
+							"side": "sell",
+							"amount": "1",
+							"price": "500"
+						}
+					]
+				}
+			`)
+
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
+			assert.Nil(t, err)
+
+			trades, err := book.AmendOrder(tt.input.OrderID, tt.input.amount, tt.input.price)
+
+			var errorStr string
+			if err != nil {
+				errorStr = err.Error()
+			}
+
+			s, err := json.Marshal(&snapshot{
+				Book:   book,
+				Trades: trades,
+				Err:    errorStr,
+			})
+
+			assert.Nil(t, err)
+			cupaloy.SnapshotT(t, s)
+		})
+	}
+}
+
+func TestAmendOrderSelfTradePrevention(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90), orderbook.WithSelfTradePrevention(orderbook.STPCancelOldest))
+	assert.Nil(t, err)
+
+	mode, ok := book.OpenOrders("1")[0].SelfTradePrevention()
+	assert.True(t, ok)
+	assert.Equal(t, orderbook.STPCancelOldest, mode)
+
+	trades, err := book.AmendOrder("2", decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+
+	assert.Empty(t, book.Depth().Asks())
+
+	orders := book.OpenOrders("1")
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "2", orders[0].ID())
+
+	mode, ok = orders[0].SelfTradePrevention()
+	assert.True(t, ok)
+	assert.Equal(t, orderbook.STPCancelOldest, mode)
+}
@@ -0,0 +1,200 @@
+package orderbook
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// IndicativePrice returns the price the auction would uncross at or nil when the book does not cross.
+//
+// The price maximizes the executable amount. Ties are broken by the smallest imbalance, then by the market pressure,
+// the highest price when bids are in surplus and the lowest when asks are, then by the closest price to the last price and finally by the lowest price.
+func (ob *OrderBook) IndicativePrice() *Equilibrium {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.equilibrium()
+}
+
+// Uncross executes every crossing order at the single clearing price, ends the auction and returns the trades.
+// The buy order is the taker of every auction trade. The order failing a trade check is rejected and cancelled.
+func (ob *OrderBook) Uncross() (trades []*Trade, err error) {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	if ob.status != Auction {
+		return nil, ErrNoAuction
+	}
+
+	if ob.now().Before(ob.resumeAt) {
+		return nil, ErrCoolingDown
+	}
+
+	if err := ob.record(&Command{commandType: UncrossCommand}); err != nil {
+		return nil, err
+	}
+
+	ob.status = Open
+	ob.resumeAt = time.Time{}
+	trades = make([]*Trade, 0)
+
+	eq := ob.equilibrium()
+	if eq == nil {
+		return trades, nil
+	}
+
+	amountToTrade := eq.amount
+
+	for amountToTrade.GreaterThan(decimal.Zero) {
+		bids := ob.bids.MaxPriceQueue()
+		asks := ob.asks.MinPriceQueue()
+
+		if bids == nil || asks == nil || bids.price.LessThan(eq.price) || asks.price.GreaterThan(eq.price) {
+			break
+		}
+
+		bidEl := bids.Front()
+		askEl := asks.Front()
+		bid := bidEl.Value.(*Order)
+		ask := askEl.Value.(*Order)
+
+		amount := decimal.Min(amountToTrade, bid.amount, ask.amount)
+
+		trade, err := ob.trade(bid, ask, amount, eq.price)
+		if err != nil {
+			failed := bid
+			if err == ErrMakerInsufficientFunds {
+				failed = ask
+			}
+
+			ob.emit(OrderRejected, failed.clone(), nil, err)
+			ob.cancelled(ob.remove(failed.id))
+			continue
+		}
+
+		ob.emit(TradeExecuted, nil, trade, nil)
+
+		ob.take(bidEl, amount, trade)
+		ob.take(askEl, amount, trade)
+
+		trades = append(trades, trade)
+		amountToTrade = amountToTrade.Sub(amount)
+	}
+
+	return ob.triggerStopOrders(trades), nil
+}
+
+// equilibrium computes the auction clearing price, counting the hidden reserve of iceberg orders.
+func (ob *OrderBook) equilibrium() *Equilibrium {
+	bids := ob.bids.MaxPriceQueue()
+	asks := ob.asks.MinPriceQueue()
+
+	if bids == nil || asks == nil || bids.price.LessThan(asks.price) {
+		return nil
+	}
+
+	type level struct {
+		price  decimal.Decimal
+		bids   decimal.Decimal
+		asks   decimal.Decimal
+		amount decimal.Decimal
+	}
+
+	prices := make(map[string]*level)
+	levels := make([]*level, 0)
+
+	at := func(price decimal.Decimal) *level {
+		l, ok := prices[price.String()]
+		if !ok {
+			l = &level{price: price}
+			prices[price.String()] = l
+			levels = append(levels, l)
+		}
+
+		return l
+	}
+
+	for q := ob.bids.MinPriceQueue(); q != nil; q = ob.bids.GreaterThan(q.price) {
+		l := at(q.price)
+		l.bids = total(q)
+	}
+
+	for q := ob.asks.MinPriceQueue(); q != nil; q = ob.asks.GreaterThan(q.price) {
+		l := at(q.price)
+		l.asks = total(q)
+	}
+
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].price.LessThan(levels[j].price)
+	})
+
+	// bids at or above the price and asks at or below it are executable.
+	cumulative := decimal.Zero
+	for i := len(levels) - 1; i >= 0; i-- {
+		cumulative = cumulative.Add(levels[i].bids)
+		levels[i].bids = cumulative
+	}
+
+	cumulative = decimal.Zero
+	for _, l := range levels {
+		cumulative = cumulative.Add(l.asks)
+		l.asks = cumulative
+		l.amount = decimal.Min(l.bids, l.asks)
+	}
+
+	var best *Equilibrium
+
+	for _, l := range levels {
+		candidate := NewEquilibrium(l.price, l.amount, l.bids.Sub(l.asks))
+		if best == nil || ob.better(candidate, best) {
+			best = candidate
+		}
+	}
+
+	if best == nil || best.amount.IsZero() {
+		return nil
+	}
+
+	return best
+}
+
+// better tells if the candidate equilibrium beats the best one so far. Candidates come in ascending price.
+func (ob *OrderBook) better(candidate, best *Equilibrium) bool {
+	if c := candidate.amount.Cmp(best.amount); c != 0 {
+		return c > 0
+	}
+
+	if c := candidate.imbalance.Abs().Cmp(best.imbalance.Abs()); c != 0 {
+		return c < 0
+	}
+
+	if candidate.imbalance.IsPositive() && best.imbalance.IsPositive() {
+		return true
+	}
+
+	if candidate.imbalance.IsNegative() && best.imbalance.IsNegative() {
+		return false
+	}
+
+	if ob.lastPrice.IsZero() {
+		return false
+	}
+
+	return candidate.price.Sub(ob.lastPrice).Abs().LessThan(best.price.Sub(ob.lastPrice).Abs())
+}
+
+// total returns the amount of a price queue counting the hidden reserve of iceberg orders.
+func total(q *OrderQueue) decimal.Decimal {
+	amount := q.amount
+	for e := q.Front(); e != nil; e = e.Next() {
+		amount = amount.Add(e.Value.(*Order).hiddenAmount)
+	}
+
+	return amount
+}
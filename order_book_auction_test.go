@@ -0,0 +1,83 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuction(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.Uncross()
+	assert.Equal(t, orderbook.ErrNoAuction, err)
+
+	assert.Nil(t, book.StartAuction())
+	assert.Equal(t, orderbook.ErrAuctionInProgress, book.StartAuction())
+
+	orders := []struct {
+		id     string
+		side   orderbook.Side
+		amount int64
+		price  int64
+	}{
+		{"1", orderbook.Buy, 5, 102},
+		{"2", orderbook.Buy, 3, 101},
+		{"3", orderbook.Buy, 4, 99},
+		{"4", orderbook.Sell, 2, 98},
+		{"5", orderbook.Sell, 4, 100},
+		{"6", orderbook.Sell, 6, 103},
+	}
+
+	for _, o := range orders {
+		trades, err := book.ProcessLimitOrder(o.id, o.id, o.side, decimal.NewFromInt(o.amount), decimal.NewFromInt(o.price))
+		assert.Nil(t, err)
+		assert.Empty(t, trades)
+	}
+
+	_, err = book.ProcessMarketOrder("7", "7", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Equal(t, orderbook.ErrAuctionInProgress, err)
+
+	_, err = book.ProcessLimitOrder("7", "7", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1000), orderbook.WithTimeInForce(orderbook.IOC))
+	assert.Equal(t, orderbook.ErrAuctionInProgress, err)
+
+	eq := book.IndicativePrice()
+	assert.NotNil(t, eq)
+	assert.Equal(t, "101", eq.Price().String())
+	assert.Equal(t, "6", eq.Amount().String())
+	assert.Equal(t, "2", eq.Imbalance().String())
+
+	trades, err := book.Uncross()
+	assert.Nil(t, err)
+
+	s, err := json.Marshal(&struct {
+		Equilibrium *orderbook.Equilibrium
+		Book        *orderbook.OrderBook
+		Trades      []*orderbook.Trade
+	}{
+		Equilibrium: eq,
+		Book:        book,
+		Trades:      trades,
+	})
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+
+	assert.Nil(t, book.IndicativePrice())
+}
+
+func TestIndicativePriceNoCross(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessPostOnlyOrder("1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(99))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessPostOnlyOrder("2", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	assert.Nil(t, book.IndicativePrice())
+}
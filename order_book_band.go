@@ -0,0 +1,41 @@
+package orderbook
+
+import "time"
+
+// PriceBand returns the circuit breaker or nil when there is none.
+func (ob *OrderBook) PriceBand() *PriceBand {
+	return ob.band
+}
+
+// ResumeAt returns when a market moved by the circuit breaker resumes or the zero time.
+func (ob *OrderBook) ResumeAt() time.Time {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.resumeAt
+}
+
+// breach moves an open market to the breach status of the price band.
+// It tells if the unfilled amount of the taker must be cancelled instead of resting, which is the case unless the market is now in auction.
+func (ob *OrderBook) breach() bool {
+	if ob.status != Open || ob.band.breachStatus == Open {
+		return true
+	}
+
+	ob.status = ob.band.breachStatus
+	if ob.band.cooldown > 0 {
+		ob.resumeAt = ob.now().Add(ob.band.cooldown)
+	}
+
+	return ob.status != Auction
+}
+
+// resume opens a market moved by the circuit breaker once the cooldown is over. An auction is only ended by Uncross.
+func (ob *OrderBook) resume() {
+	if ob.resumeAt.IsZero() || ob.status == Auction || ob.now().Before(ob.resumeAt) {
+		return
+	}
+
+	ob.status = Open
+	ob.resumeAt = time.Time{}
+}
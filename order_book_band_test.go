@@ -0,0 +1,139 @@
+package orderbook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceBand(t *testing.T) {
+	tests := []struct {
+		referencePrice string
+		static         string
+		dynamic        string
+		price          string
+		lastPrice      string
+		allows         bool
+	}{
+		{"100", "0.1", "0", "110", "0", true},
+		{"100", "0.1", "0", "111", "0", false},
+		{"100", "0.1", "0", "89", "0", false},
+		{"0", "0.1", "0.05", "104", "100", true},
+		{"0", "0.1", "0.05", "106", "100", false},
+		{"0", "0.1", "0.05", "106", "0", true},
+		{"100", "0.1", "0.05", "109", "105", true},
+		{"100", "0.1", "0.05", "99", "105", false},
+	}
+
+	for _, tt := range tests {
+		band := orderbook.NewPriceBand(decimal.RequireFromString(tt.referencePrice), decimal.RequireFromString(tt.static), decimal.RequireFromString(tt.dynamic), orderbook.Open, 0)
+		assert.Equal(t, tt.allows, band.Allows(decimal.RequireFromString(tt.price), decimal.RequireFromString(tt.lastPrice)), tt)
+	}
+
+	var band *orderbook.PriceBand
+	assert.True(t, band.Allows(decimal.NewFromInt(1), decimal.NewFromInt(1000)))
+}
+
+func TestPriceBandStopsMatching(t *testing.T) {
+	band := orderbook.NewPriceBand(decimal.NewFromInt(100), decimal.RequireFromString("0.1"), decimal.Zero, orderbook.Open, 0)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithPriceBand(band))
+
+	for i, price := range []int64{100, 95, 80} {
+		_, err := book.ProcessLimitOrder(string(rune('1'+i)), "maker", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(price))
+		assert.Nil(t, err)
+	}
+
+	execution, err := book.ProcessMarketOrder("4", "taker", orderbook.Sell, decimal.NewFromInt(3))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 2)
+	assert.Equal(t, "1", execution.Remaining().String())
+	assert.Equal(t, orderbook.Open, book.Status())
+
+	trades, err := book.ProcessLimitOrder("5", "taker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(70))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+	assert.Len(t, book.Depth().Asks(), 0)
+	assert.Len(t, book.Depth().Bids(), 1)
+}
+
+func TestPriceBandHalt(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	band := orderbook.NewPriceBand(decimal.Zero, decimal.Zero, decimal.RequireFromString("0.05"), orderbook.Halted, time.Minute)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithPriceBand(band), orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "maker", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "maker", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	execution, err := book.ProcessMarketOrder("3", "taker", orderbook.Sell, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 1)
+
+	trades, err := book.ProcessLimitOrder("4", "taker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+	assert.Equal(t, orderbook.Halted, book.Status())
+	assert.Equal(t, now.Add(time.Minute), book.ResumeAt())
+	assert.Len(t, book.Depth().Asks(), 0)
+
+	_, err = book.ProcessLimitOrder("5", "taker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Equal(t, orderbook.ErrMarketHalted, err)
+
+	now = now.Add(time.Minute)
+
+	trades, err = book.ProcessLimitOrder("5", "taker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(96))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+	assert.Equal(t, orderbook.Open, book.Status())
+	assert.True(t, book.ResumeAt().IsZero())
+}
+
+func TestPriceBandAuction(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	band := orderbook.NewPriceBand(decimal.NewFromInt(100), decimal.RequireFromString("0.1"), decimal.Zero, orderbook.Auction, time.Minute)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithPriceBand(band), orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "maker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(120))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("2", "taker", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(125))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+	assert.Equal(t, orderbook.Auction, book.Status())
+	assert.Len(t, book.Depth().Bids(), 1)
+
+	_, err = book.Uncross()
+	assert.Equal(t, orderbook.ErrCoolingDown, err)
+
+	now = now.Add(time.Minute)
+
+	trades, err = book.Uncross()
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, orderbook.Open, book.Status())
+}
+
+func TestPriceBandFillOrKill(t *testing.T) {
+	band := orderbook.NewPriceBand(decimal.NewFromInt(100), decimal.RequireFromString("0.05"), decimal.Zero, orderbook.Open, 0)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithPriceBand(band))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("3", "2", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(110), orderbook.WithTimeInForce(orderbook.FOK))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+	assert.Len(t, book.Depth().Asks(), 2)
+}
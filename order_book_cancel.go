@@ -2,14 +2,42 @@ package orderbook
 
 // CancelOrder canacels an order.
 func (ob *OrderBook) CancelOrder(orderID string) *Order {
+	order, _ := ob.CancelOrderErr(orderID)
+	return order
+}
+
+// CancelOrderErr cancels an order like CancelOrder and returns why it was not, e.g. ErrOrderNotFound or ErrMarketHalted.
+func (ob *OrderBook) CancelOrderErr(orderID string) (order *Order, err error) {
 	defer func() {
+		if err != nil {
+			ob.emit(OrderRejected, &Order{id: orderID}, nil, err)
+		}
+
 		ob.version++
 		ob.Unlock()
 	}()
 
 	ob.Lock()
 
-	return ob.remove(orderID)
+	if err = ob.allow(CancelCommand); err != nil {
+		return nil, err
+	}
+
+	if ob.orders[orderID] == nil && ob.stops[orderID] == nil {
+		return nil, ErrOrderNotFound
+	}
+
+	if err = ob.record(&Command{commandType: CancelCommand, orderID: orderID}); err != nil {
+		return nil, err
+	}
+
+	order = ob.remove(orderID)
+	if order == nil {
+		order = ob.removeStop(orderID).order
+	}
+
+	ob.emit(OrderCancelled, order.clone(), nil, nil)
+	return order, nil
 }
 
 func (ob *OrderBook) remove(orderID string) *Order {
@@ -19,6 +47,7 @@ func (ob *OrderBook) remove(orderID string) *Order {
 	}
 
 	delete(ob.orders, orderID)
+	ob.untrack(e.Value.(*Order).traderID)
 
 	if e.Value.(*Order).side == Buy {
 		return ob.bids.Remove(e)
@@ -26,3 +55,19 @@ func (ob *OrderBook) remove(orderID string) *Order {
 
 	return ob.asks.Remove(e)
 }
+
+func (ob *OrderBook) removeStop(orderID string) *StopOrder {
+	e, ok := ob.stops[orderID]
+	if !ok {
+		return nil
+	}
+
+	delete(ob.stops, orderID)
+	ob.untrack(e.Value.(*StopOrder).order.traderID)
+
+	if e.Value.(*StopOrder).order.side == Buy {
+		return ob.stopBuys.Remove(e)
+	}
+
+	return ob.stopSells.Remove(e)
+}
@@ -0,0 +1,139 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func denseBook(t testing.TB) *orderbook.OrderBook {
+	dense, err := orderbook.WithDenseLevels(decimal.NewFromInt(1), decimal.NewFromInt(50), decimal.NewFromInt(150))
+	assert.Nil(t, err)
+
+	return orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock), dense)
+}
+
+func TestDenseLevels(t *testing.T) {
+	book := denseBook(t)
+
+	for i, price := range []string{"100", "101", "101.5", "160", "40", "150", "50"} {
+		id := strconv.Itoa(i)
+		_, err := book.ProcessLimitOrder(id, id, orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString(price))
+		assert.Nil(t, err)
+	}
+
+	prices := make([]string, 0)
+	for _, order := range book.Depth().Asks() {
+		prices = append(prices, order.Price().String())
+	}
+
+	assert.Equal(t, []string{"40", "50", "100", "101", "101.5", "150", "160"}, prices)
+
+	trades, err := book.ProcessLimitOrder("7", "7", orderbook.Buy, decimal.NewFromInt(4), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 4)
+	assert.Equal(t, "101", trades[3].Price().String())
+
+	assert.NotNil(t, book.CancelOrder("2"))
+	assert.NotNil(t, book.CancelOrder("3"))
+	assert.NotNil(t, book.CancelOrder("5"))
+	assert.Empty(t, book.Depth().Asks())
+
+	_, err = book.ProcessLimitOrder("8", "8", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(120))
+	assert.Nil(t, err)
+	assert.Equal(t, "120", book.Depth().Asks()[0].Price().String())
+}
+
+func TestDenseLevelsMatchTree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+	dense := denseBook(t)
+
+	for i := 0; i < 2000; i++ {
+		id := strconv.Itoa(i)
+		side := orderbook.Side(r.Intn(2))
+		amount := decimal.NewFromInt(int64(r.Intn(10) + 1))
+		price := decimal.NewFromInt(int64(r.Intn(140) + 30))
+		if r.Intn(10) == 0 {
+			price = price.Add(decimal.RequireFromString("0.5"))
+		}
+
+		var treeTrades, denseTrades interface{}
+		switch r.Intn(4) {
+		case 0:
+			treeTrades, _ = tree.ProcessMarketOrder(id, id, side, amount)
+			denseTrades, _ = dense.ProcessMarketOrder(id, id, side, amount)
+		case 1:
+			cancel := strconv.Itoa(r.Intn(i + 1))
+			treeTrades, denseTrades = tree.CancelOrder(cancel), dense.CancelOrder(cancel)
+		default:
+			treeTrades, _ = tree.ProcessLimitOrder(id, id, side, amount, price)
+			denseTrades, _ = dense.ProcessLimitOrder(id, id, side, amount, price)
+		}
+
+		expected, _ := json.Marshal(treeTrades)
+		actual, _ := json.Marshal(denseTrades)
+		assert.JSONEq(t, string(expected), string(actual))
+	}
+
+	expected, _ := json.Marshal(tree.Depth())
+	actual, _ := json.Marshal(dense.Depth())
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestDenseLevelsValidations(t *testing.T) {
+	_, err := orderbook.WithDenseLevels(decimal.Zero, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Equal(t, orderbook.ErrInvalidTickSize, err)
+
+	_, err = orderbook.WithDenseLevels(decimal.NewFromInt(1), decimal.NewFromInt(100), decimal.NewFromInt(1))
+	assert.Equal(t, orderbook.ErrInvalidPrice, err)
+
+	_, err = orderbook.NewDenseOrderSide(orderbook.Buy, decimal.RequireFromString("0.00000001"), decimal.Zero, decimal.NewFromInt(1000000))
+	assert.Equal(t, orderbook.ErrTooManyPriceLevels, err)
+
+	side, err := orderbook.NewDenseOrderSide(orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(orderbook.MaxDenseLevels))
+	assert.Nil(t, err)
+	assert.NotNil(t, side)
+}
+
+func BenchmarkDenseMatching1000(b *testing.B)  { benchmarkDenseMatching(1000, b) }
+func BenchmarkDenseMatching10000(b *testing.B) { benchmarkDenseMatching(10000, b) }
+
+func benchmarkDenseMatching(l int, b *testing.B) {
+	orders := benchmarkOrders(l)
+
+	dense, err := orderbook.WithDenseLevels(decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		book := orderbook.NewOrderBook("USD/BTC", dense)
+		for _, o := range orders {
+			book.ProcessLimitOrder(o.id, o.id, o.side, o.amount, o.price)
+		}
+	}
+}
+
+func BenchmarkDepth(b *testing.B)      { benchmarkDepth(orderbook.NewOrderBook("USD/BTC"), b) }
+func BenchmarkDenseDepth(b *testing.B) { benchmarkDepth(denseBook(b), b) }
+
+func benchmarkDepth(book *orderbook.OrderBook, b *testing.B) {
+	for i := 50; i <= 150; i++ {
+		id := strconv.Itoa(i)
+		book.ProcessLimitOrder(id, id, orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(int64(i)))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		book.Depth()
+	}
+}
@@ -1,25 +1,67 @@
 package orderbook
 
-// Depth retruns the depth.
-func (ob *OrderBook) Depth() *Depth {
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Depth retruns the depth, best prices first on both sides.
+func (ob *OrderBook) Depth(opts ...DepthOption) *Depth {
 	defer ob.RUnlock()
 	ob.RLock()
 
-	asks := make([]*PriceLevel, 0)
-	level := ob.asks.MaxPriceQueue()
+	return ob.depth(newDepthOptions(opts))
+}
 
-	for level != nil {
-		asks = append(asks, NewPriceLevel(level.price, level.amount))
-		level = ob.asks.LessThan(level.price)
-	}
+func (ob *OrderBook) depth(o depthOptions) *Depth {
+	return &Depth{ob.levels(ob.bids, o), ob.levels(ob.asks, o), ob.version}
+}
+
+// levels aggregates the price queues of a side, best first, into the levels asked for by the options.
+func (ob *OrderBook) levels(side *OrderSide, o depthOptions) []*PriceLevel {
+	levels := make([]*PriceLevel, 0)
+	amount, notional := decimal.Zero, decimal.Zero
+
+	side.each(func(q *OrderQueue) bool {
+		price := bucket(q.price, side.side, o.grouping)
+
+		var level *PriceLevel
+		if n := len(levels); n > 0 && levels[n-1].price.Equal(price) {
+			level = levels[n-1]
+		} else if o.limit > 0 && n == o.limit {
+			return false
+		} else {
+			level = NewPriceLevel(price, decimal.Zero)
+			levels = append(levels, level)
+		}
 
-	bids := make([]*PriceLevel, 0)
-	level = ob.bids.MaxPriceQueue()
+		level.amount = level.amount.Add(q.amount)
+
+		if o.counts {
+			level.orders += q.Len()
+		}
+
+		if o.cumulative {
+			amount = amount.Add(q.amount)
+			notional = notional.Add(q.amount.Mul(q.price))
+			level.cumulativeAmount, level.cumulativeNotional = amount, notional
+		}
+
+		return true
+	})
+
+	return levels
+}
+
+// bucket rounds a price to a multiple of the step, down for bids and up for asks. A step of zero keeps the price.
+func bucket(price decimal.Decimal, side Side, step decimal.Decimal) decimal.Decimal {
+	if !step.IsPositive() {
+		return price
+	}
 
-	for level != nil {
-		bids = append(bids, NewPriceLevel(level.price, level.amount))
-		level = ob.bids.LessThan(level.price)
+	q, r := price.QuoRem(step, 0)
+	if !r.IsZero() && side == Sell {
+		q = q.Add(decimal.NewFromInt(1))
 	}
 
-	return &Depth{bids, asks}
+	return q.Mul(step)
 }
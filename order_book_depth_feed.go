@@ -0,0 +1,32 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// SubscribeDepth registers a listener for the price level changes, which keep a Depth snapshot up to date.
+func (ob *OrderBook) SubscribeDepth(listener DepthListener) {
+	defer ob.Unlock()
+	ob.Lock()
+
+	ob.depthListeners = append(ob.depthListeners, listener)
+}
+
+// watch hooks the depth feed on the sides of the book.
+func (ob *OrderBook) watch() {
+	ob.asks.onChange = ob.depthChanged
+	ob.bids.onChange = ob.depthChanged
+	ob.asks.onOrder = ob.orderChanged
+	ob.bids.onOrder = ob.orderChanged
+}
+
+func (ob *OrderBook) depthChanged(side Side, price, amount decimal.Decimal) {
+	if len(ob.depthListeners) == 0 {
+		return
+	}
+
+	update := NewDepthUpdate(side, price, amount, ob.version+1)
+	for _, listener := range ob.depthListeners {
+		listener.OnDepthUpdate(update)
+	}
+}
@@ -2,10 +2,12 @@ package orderbook_test
 
 import (
 	"encoding/json"
+	"strconv"
 	"testing"
 
 	"github.com/bradleyjkemp/cupaloy/v2"
 	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -90,3 +92,85 @@ func TestDepthAsks(t *testing.T) {
 	assert.Nil(t, err)
 	cupaloy.SnapshotT(t, s)
 }
+
+func TestDepthUpdates(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD")
+
+	_, err := book.ProcessPostOnlyOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	snapshot := book.Depth()
+
+	updates := make([]*orderbook.DepthUpdate, 0)
+	book.SubscribeDepth(orderbook.DepthListenerFunc(func(update *orderbook.DepthUpdate) {
+		updates = append(updates, update)
+	}))
+
+	_, err = book.ProcessPostOnlyOrder("2", "2", orderbook.Sell, decimal.NewFromInt(3), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("3", "3", orderbook.Buy, decimal.NewFromInt(6), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessPostOnlyOrder("4", "4", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, book.CancelOrder("4"))
+
+	levels := map[string]string{}
+	for _, level := range snapshot.Asks() {
+		levels["sell "+level.Price().String()] = level.Amount().String()
+	}
+
+	for _, update := range updates {
+		assert.Greater(t, update.Version(), snapshot.Version())
+		levels[update.Side().String()+" "+update.Price().String()] = update.Amount().String()
+	}
+
+	assert.Equal(t, map[string]string{"sell 100": "0", "buy 100": "1", "buy 90": "0"}, levels)
+
+	s, err := json.Marshal(updates)
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
+
+func TestDepthOptions(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD")
+
+	orders := []struct {
+		side   orderbook.Side
+		amount string
+		price  string
+	}{
+		{orderbook.Buy, "1", "99.99"},
+		{orderbook.Buy, "2", "99.50"},
+		{orderbook.Buy, "1", "99.50"},
+		{orderbook.Buy, "3", "98.01"},
+		{orderbook.Buy, "1", "97"},
+		{orderbook.Sell, "1", "100.01"},
+		{orderbook.Sell, "2", "100.99"},
+		{orderbook.Sell, "1", "101"},
+		{orderbook.Sell, "4", "102.50"},
+	}
+
+	for i, o := range orders {
+		id := strconv.Itoa(i)
+		_, err := book.ProcessPostOnlyOrder(id, id, o.side, decimal.RequireFromString(o.amount), decimal.RequireFromString(o.price))
+		assert.Nil(t, err)
+	}
+
+	depth := book.Depth()
+	assert.Equal(t, "99.99", depth.Bids()[0].Price().String())
+	assert.Equal(t, "100.01", depth.Asks()[0].Price().String())
+	assert.Equal(t, 0, depth.Asks()[0].Orders())
+
+	depth = book.Depth(orderbook.WithDepthLimit(2), orderbook.WithDepthGrouping(decimal.NewFromInt(1)), orderbook.WithOrderCounts(), orderbook.WithCumulative())
+	assert.Len(t, depth.Bids(), 2)
+	assert.Len(t, depth.Asks(), 2)
+
+	s, err := json.Marshal(depth)
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
@@ -0,0 +1,125 @@
+package orderbook
+
+import (
+	"container/list"
+
+	"github.com/shopspring/decimal"
+)
+
+// Subscribe registers a listener for the order book events.
+func (ob *OrderBook) Subscribe(listener Listener) {
+	defer ob.Unlock()
+	ob.Lock()
+
+	ob.listeners = append(ob.listeners, listener)
+}
+
+// emit delivers an event to the listeners. Events are tagged with the version the current change produces.
+func (ob *OrderBook) emit(eventType EventType, order *Order, trade *Trade, err error) {
+	if len(ob.listeners) == 0 {
+		return
+	}
+
+	event := NewEvent(eventType, ob.version+1, order, trade, err)
+	for _, listener := range ob.listeners {
+		listener.OnEvent(event)
+	}
+}
+
+// match trades an amount of the resting order e, removing it when nothing is left.
+// It returns the trade and the next resting order to match, or the error of a trade check and e.
+// A maker the trade check finds short of funds is cancelled instead, returning no trade and the next resting order.
+func (ob *OrderBook) match(taker *Order, e *list.Element, amount decimal.Decimal) (*Trade, *list.Element, error) {
+	maker := e.Value.(*Order)
+
+	trade, err := ob.trade(taker, maker, amount, maker.price)
+	if err == ErrMakerInsufficientFunds {
+		next := e.Next()
+		ob.cancelled(ob.remove(maker.id))
+		return nil, next, nil
+	}
+
+	if err != nil {
+		return nil, e, err
+	}
+
+	ob.emit(TradeExecuted, nil, trade, nil)
+	return trade, ob.take(e, amount, trade), nil
+}
+
+// trade creates the next trade, tagged with the version the current change produces and the book clock time, and charges its fees.
+// The trade is not created when a trade check fails.
+func (ob *OrderBook) trade(taker, maker *Order, amount, price decimal.Decimal) (*Trade, error) {
+	trade := NewTrade(ob.tradeID+1, ob.version+1, ob.now(), taker.id, maker.id, taker.traderID, maker.traderID, taker.side, amount, price)
+
+	if ob.fees != nil {
+		trade.makerFee, trade.takerFee, trade.feeCurrency = ob.fees.Fees(ob.symbol, trade)
+	}
+
+	if err := ob.checkTrade(trade); err != nil {
+		return nil, err
+	}
+
+	ob.tradeID++
+	return trade, nil
+}
+
+// take removes the traded amount from the resting order e, removing it when nothing is left.
+// It returns the next resting order to match.
+func (ob *OrderBook) take(e *list.Element, amount decimal.Decimal, trade *Trade) *list.Element {
+	order := e.Value.(*Order)
+
+	ob.executing = true
+
+	var next *list.Element
+	if amount.LessThan(order.amount) {
+		ob.sideOf(order).UpdateAmount(e, order.amount.Sub(amount))
+		next = e.Next()
+	} else {
+		next = ob.fill(e)
+	}
+
+	ob.executing = false
+
+	if _, ok := ob.orders[order.id]; ok {
+		ob.emit(OrderPartiallyFilled, order.clone(), trade, nil)
+	} else {
+		filled := order.clone()
+		filled.amount = decimal.Zero
+		ob.emit(OrderFilled, filled, trade, nil)
+	}
+
+	return next
+}
+
+// taken reports how much of the taker was filled by the trades.
+func (ob *OrderBook) taken(taker *Order, trades []*Trade) {
+	if len(trades) == 0 {
+		return
+	}
+
+	left := taker.clone()
+	for _, trade := range trades {
+		if taker.funds.IsPositive() {
+			left.funds = left.funds.Sub(trade.amount.Mul(trade.price))
+		} else {
+			left.amount = left.amount.Sub(trade.amount)
+		}
+	}
+
+	if left.amount.GreaterThan(decimal.Zero) || left.funds.GreaterThan(decimal.Zero) {
+		ob.emit(OrderPartiallyFilled, left, nil, nil)
+	} else {
+		left.amount = decimal.Zero
+		ob.emit(OrderFilled, left, nil, nil)
+	}
+}
+
+// cancelled reports an order, or the unfilled amount of a taker, cancelled by the book itself.
+func (ob *OrderBook) cancelled(order *Order) {
+	if ob.onCancel != nil {
+		ob.onCancel(order)
+	}
+
+	ob.emit(OrderCancelled, order.clone(), nil, nil)
+}
@@ -0,0 +1,42 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	events := make([]*orderbook.Event, 0)
+	book.Subscribe(orderbook.ListenerFunc(func(event *orderbook.Event) {
+		events = append(events, event)
+	}))
+
+	_, err := book.ProcessPostOnlyOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Buy, decimal.NewFromInt(3), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessMarketOrder("3", "3", orderbook.Sell, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("4", "4", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(200), orderbook.WithTimeInForce(orderbook.IOC))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("5", "5", orderbook.Sell, decimal.NewFromInt(0), decimal.NewFromInt(200))
+	assert.Equal(t, orderbook.ErrInvalidAmount, err)
+
+	assert.Nil(t, book.CancelOrder("foo"))
+
+	s, err := json.Marshal(events)
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
@@ -0,0 +1,56 @@
+package orderbook
+
+import (
+	"container/list"
+	"sort"
+	"time"
+)
+
+// ExpireOrders removes every GTD order expired at the given time and returns them sorted by expire time.
+// Nothing is removed when the journal can not record the command.
+func (ob *OrderBook) ExpireOrders(now time.Time) []*Order {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	expired := make([]*Order, 0)
+
+	for _, e := range ob.orders {
+		if order := e.Value.(*Order); order.expired(now) {
+			expired = append(expired, order)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		if expired[i].expireAt.Equal(expired[j].expireAt) {
+			return expired[i].id < expired[j].id
+		}
+
+		return expired[i].expireAt.Before(expired[j].expireAt)
+	})
+
+	if len(expired) == 0 {
+		return expired
+	}
+
+	if err := ob.record(&Command{commandType: ExpireCommand, time: now}); err != nil {
+		return make([]*Order, 0)
+	}
+
+	for _, order := range expired {
+		ob.cancelled(ob.remove(order.id))
+	}
+
+	return expired
+}
+
+// expire removes the expired resting order e met while matching and returns the next resting order to match.
+func (ob *OrderBook) expire(e *list.Element) *list.Element {
+	next := e.Next()
+	ob.cancelled(ob.remove(e.Value.(*Order).id))
+
+	return next
+}
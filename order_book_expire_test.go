@@ -0,0 +1,80 @@
+package orderbook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireOrders(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(func() time.Time { return now }))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		orderbook.WithTimeInForce(orderbook.GTD), orderbook.WithExpireTime(now.Add(2*time.Minute)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(200),
+		orderbook.WithTimeInForce(orderbook.GTD), orderbook.WithExpireTime(now.Add(time.Minute)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("3", "3", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(300))
+	assert.Nil(t, err)
+
+	assert.Empty(t, book.ExpireOrders(now))
+
+	expired := book.ExpireOrders(now.Add(5 * time.Minute))
+	assert.Len(t, expired, 2)
+	assert.Equal(t, "2", expired[0].ID())
+	assert.Equal(t, "1", expired[1].ID())
+
+	assert.Empty(t, book.Depth().Bids())
+	assert.Len(t, book.Depth().Asks(), 1)
+}
+
+func TestExpireOrdersWhileMatching(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(func() time.Time { return now }))
+
+	cancelled := make([]string, 0)
+	book.Subscribe(orderbook.ListenerFunc(func(event *orderbook.Event) {
+		if event.Type() == orderbook.OrderCancelled {
+			cancelled = append(cancelled, event.Order().ID())
+		}
+	}))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100),
+		orderbook.WithTimeInForce(orderbook.GTD), orderbook.WithExpireTime(now.Add(time.Minute)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("3", "3", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(110),
+		orderbook.WithTimeInForce(orderbook.GTD), orderbook.WithExpireTime(now.Add(time.Minute)))
+	assert.Nil(t, err)
+
+	now = now.Add(2 * time.Minute)
+
+	_, err = book.ProcessLimitOrder("4", "4", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(110),
+		orderbook.WithTimeInForce(orderbook.FOK))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"4"}, cancelled)
+
+	trades, err := book.ProcessLimitOrder("5", "4", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, "2", trades[0].MakerOrderID())
+	assert.Equal(t, []string{"4", "1"}, cancelled)
+
+	execution, err := book.ProcessMarketOrder("6", "4", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+	assert.Empty(t, execution.Trades())
+	assert.Equal(t, []string{"4", "1", "3", "6"}, cancelled)
+
+	assert.Empty(t, book.Depth().Asks())
+	assert.Empty(t, book.OpenOrders("3"))
+}
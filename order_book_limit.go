@@ -6,20 +6,28 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// ProcessLimitOrder processes a limit order.
-func (ob *OrderBook) ProcessLimitOrder(orderID, traderID string, side Side, amount, price decimal.Decimal) ([]*Trade, error) {
+// ProcessLimitOrder processes a limit order. The unfilled amount is handled according to the time in force, GTC by default.
+func (ob *OrderBook) ProcessLimitOrder(orderID, traderID string, side Side, amount, price decimal.Decimal, opts ...OrderOption) (trades []*Trade, err error) {
 	defer func() {
+		if err != nil {
+			ob.emit(OrderRejected, NewOrder(orderID, traderID, side, amount, price), nil, err)
+		}
+
 		ob.version++
 		ob.Unlock()
 	}()
 
 	ob.Lock()
 
+	if err := ob.allow(LimitCommand); err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(orderID) == "" {
 		return nil, ErrInvalidOrderID
 	}
 
-	if ob.orders[orderID] != nil {
+	if ob.orders[orderID] != nil || ob.stops[orderID] != nil {
 		return nil, ErrOrderAlreadyExists
 	}
 
@@ -35,23 +43,74 @@ func (ob *OrderBook) ProcessLimitOrder(orderID, traderID string, side Side, amou
 		return nil, ErrInvalidPrice
 	}
 
+	if err := ob.instrument.Validate(amount, price); err != nil {
+		return nil, err
+	}
+
+	o := newOrderOptions(opts)
+
+	if o.displayAmount.LessThan(decimal.Zero) {
+		return nil, ErrInvalidDisplayAmount
+	}
+
+	if o.displayAmount.IsPositive() && ob.instrument != nil && ob.instrument.lotSize.IsPositive() && !o.displayAmount.Mod(ob.instrument.lotSize).IsZero() {
+		return nil, ErrInvalidLotSize
+	}
+
+	if !ob.selfTradePrevention(o).valid() {
+		return nil, ErrInvalidSelfTradePrevention
+	}
+
+	switch o.timeInForce {
+	case GTC, IOC, FOK:
+	case GTD:
+		if !o.expireAt.After(ob.now()) {
+			return nil, ErrInvalidExpireTime
+		}
+	default:
+		return nil, ErrInvalidTimeInForce
+	}
+
+	if ob.status == Auction && (o.timeInForce == IOC || o.timeInForce == FOK) {
+		return nil, ErrAuctionInProgress
+	}
+
+	if err := ob.check(NewOrder(orderID, traderID, side, amount, price)); err != nil {
+		return nil, err
+	}
+
+	if err := ob.record(&Command{commandType: LimitCommand, orderID: orderID, traderID: traderID, side: side, amount: amount, price: price, options: o}); err != nil {
+		return nil, err
+	}
+
+	ob.emit(OrderAccepted, NewOrder(orderID, traderID, side, amount, price), nil, nil)
+
+	if o.timeInForce == FOK && ob.fillable(traderID, side, amount, price, ob.selfTradePrevention(o)).LessThan(amount) {
+		ob.cancelled(NewOrder(orderID, traderID, side, amount, price))
+		return make([]*Trade, 0), nil
+	}
+
+	trades, err = ob.processLimit(orderID, traderID, side, amount, price, o)
+	return ob.triggerStopOrders(trades), err
+}
+
+// processLimit matches a limit order and rests what is left. When a trade check fails, what is left is cancelled
+// and the trades made so far are returned with the error.
+func (ob *OrderBook) processLimit(orderID, traderID string, side Side, amount, price decimal.Decimal, o orderOptions) ([]*Trade, error) {
 	var (
-		sideToAdd     *OrderSide
-		sideToProcess *OrderSide
-		comparator    func(decimal.Decimal) bool
-		best          func() *OrderQueue
-		next          func(decimal.Decimal) *OrderQueue
+		sideToAdd  *OrderSide
+		comparator func(decimal.Decimal) bool
+		best       func() *OrderQueue
+		next       func(decimal.Decimal) *OrderQueue
 	)
 
 	if side == Buy {
 		sideToAdd = ob.bids
-		sideToProcess = ob.asks
 		comparator = price.GreaterThanOrEqual
 		best = ob.asks.MinPriceQueue
 		next = ob.asks.GreaterThan
 	} else {
 		sideToAdd = ob.asks
-		sideToProcess = ob.bids
 		comparator = price.LessThanOrEqual
 		best = ob.bids.MaxPriceQueue
 		next = ob.bids.LessThan
@@ -60,39 +119,127 @@ func (ob *OrderBook) ProcessLimitOrder(orderID, traderID string, side Side, amou
 	trades := make([]*Trade, 0)
 	amountToTrade := amount
 	bestPrice := best()
+	stp := ob.selfTradePrevention(o)
+	taker := NewOrder(orderID, traderID, side, amount, price)
+	lastPrice := ob.lastPrice
+	breached := false
+	now := ob.now()
+
+	var err error
+
+	if ob.status == Auction {
+		bestPrice = nil
+	}
+
+	for bestPrice != nil && amountToTrade.GreaterThan(decimal.Zero) && comparator(bestPrice.price) && err == nil {
+		if !ob.band.Allows(bestPrice.price, lastPrice) {
+			breached = ob.breach()
+			break
+		}
 
-	for bestPrice != nil && amountToTrade.GreaterThan(decimal.Zero) && comparator(bestPrice.price) {
 		headOrderEl := bestPrice.Front()
 		bestPrice = next(bestPrice.price)
 
 		for headOrderEl != nil && amountToTrade.GreaterThan(decimal.Zero) {
 			headOrder := headOrderEl.Value.(*Order)
 
-			if headOrder.traderID == traderID {
-				headOrderEl = headOrderEl.Next()
+			if headOrder.expired(now) {
+				headOrderEl = ob.expire(headOrderEl)
+				continue
+			}
+
+			if headOrder.traderID == traderID && stp != STPNone {
+				headOrderEl, amountToTrade = ob.preventSelfTrade(stp, taker, headOrderEl, amountToTrade)
 				continue
 			}
 
-			if amountToTrade.GreaterThanOrEqual(headOrder.amount) {
-				trades = append(trades, NewTrade(orderID, headOrder.id, headOrder.amount, headOrder.price))
-				amountToTrade = amountToTrade.Sub(headOrder.amount)
+			tradeAmount := decimal.Min(amountToTrade, headOrder.amount)
 
-				headOrderEl = headOrderEl.Next()
-				ob.remove(headOrder.id)
-			} else {
-				trades = append(trades, NewTrade(orderID, headOrder.id, amountToTrade, headOrder.price))
-				sideToProcess.UpdateAmount(headOrderEl, headOrder.amount.Sub(amountToTrade))
-				amountToTrade = decimal.Zero
+			var trade *Trade
+			if trade, headOrderEl, err = ob.match(taker, headOrderEl, tradeAmount); err != nil {
+				breached = true
+				break
+			}
 
-				headOrderEl = headOrderEl.Next()
+			if trade == nil {
+				continue
 			}
+
+			trades = append(trades, trade)
+			amountToTrade = amountToTrade.Sub(tradeAmount)
 		}
 	}
 
-	if amountToTrade.GreaterThan(decimal.Zero) {
+	ob.taken(taker, trades)
+
+	if amountToTrade.GreaterThan(decimal.Zero) && (o.timeInForce == GTC || o.timeInForce == GTD) && !breached {
 		order := NewOrder(orderID, traderID, side, amountToTrade, price)
+		if o.timeInForce == GTD {
+			order.expireAt = o.expireAt
+		}
+
+		order.selfTradePrevention = o.selfTradePrevention
+
+		if o.displayAmount.IsPositive() && o.displayAmount.LessThan(amountToTrade) {
+			order.displayAmount = o.displayAmount
+			order.hiddenAmount = amountToTrade.Sub(o.displayAmount)
+			order.amount = o.displayAmount
+		}
+
 		ob.orders[order.id] = sideToAdd.Append(order)
+		ob.traders[order.traderID]++
+		ob.emit(OrderRested, order.clone(), nil, nil)
+	} else if amountToTrade.GreaterThan(decimal.Zero) {
+		ob.cancelled(NewOrder(orderID, traderID, side, amountToTrade, price))
+	}
+
+	return trades, err
+}
+
+// fillable returns how much of the amount can be filled up to the limit price, within the price band, without changing the book.
+func (ob *OrderBook) fillable(traderID string, side Side, amount, price decimal.Decimal, stp SelfTradePrevention) decimal.Decimal {
+	var (
+		comparator func(decimal.Decimal) bool
+		level      *OrderQueue
+		next       func(decimal.Decimal) *OrderQueue
+	)
+
+	if side == Buy {
+		comparator = price.GreaterThanOrEqual
+		level = ob.asks.MinPriceQueue()
+		next = ob.asks.GreaterThan
+	} else {
+		comparator = price.LessThanOrEqual
+		level = ob.bids.MaxPriceQueue()
+		next = ob.bids.LessThan
+	}
+
+	filled := decimal.Zero
+	now := ob.now()
+
+	for level != nil && filled.LessThan(amount) && comparator(level.price) && ob.band.Allows(level.price, ob.lastPrice) {
+		for e := level.Front(); e != nil && filled.LessThan(amount); e = e.Next() {
+			order := e.Value.(*Order)
+
+			if order.expired(now) {
+				continue
+			}
+
+			if order.traderID == traderID {
+				switch stp {
+				case STPNone:
+				case STPCancelNewest, STPCancelBoth, STPDecrementAndCancel:
+					return filled
+				default:
+					continue
+				}
+			}
+
+			filled = filled.Add(order.amount).Add(order.hiddenAmount)
+		}
+
+		level = next(level.price)
 	}
 
-	return trades, nil
+	return decimal.Min(filled, amount)
 }
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy/v2"
 	"github.com/danielgatis/go-orderbook"
@@ -114,8 +115,8 @@ func TestProcessLimitOrderBids(t *testing.T) {
 				}
 			`)
 
-			var book orderbook.OrderBook
-			err := json.Unmarshal(given, &book)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
 			assert.Nil(t, err)
 
 			trades, err := book.ProcessLimitOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price)
@@ -126,7 +127,7 @@ func TestProcessLimitOrderBids(t *testing.T) {
 			}
 
 			s, err := json.Marshal(&snapshot{
-				Book:   &book,
+				Book:   book,
 				Trades: trades,
 				Err:    errorStr,
 			})
@@ -229,8 +230,8 @@ func TestProcessLimitOrderAsks(t *testing.T) {
 				}
 			`)
 
-			var book orderbook.OrderBook
-			err := json.Unmarshal(given, &book)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
 			assert.Nil(t, err)
 
 			trades, err := book.ProcessLimitOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price)
@@ -241,7 +242,7 @@ func TestProcessLimitOrderAsks(t *testing.T) {
 			}
 
 			s, err := json.Marshal(&snapshot{
-				Book:   &book,
+				Book:   book,
 				Trades: trades,
 				Err:    errorStr,
 			})
@@ -354,8 +355,8 @@ func TestProcessLimitOrderValidations(t *testing.T) {
 				}
 			`)
 
-			var book orderbook.OrderBook
-			err := json.Unmarshal(given, &book)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
 			assert.Nil(t, err)
 
 			trades, err := book.ProcessLimitOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price)
@@ -366,7 +367,7 @@ func TestProcessLimitOrderValidations(t *testing.T) {
 			}
 
 			s, err := json.Marshal(&snapshot{
-				Book:   &book,
+				Book:   book,
 				Trades: trades,
 				Err:    errorStr,
 			})
@@ -377,6 +378,218 @@ func TestProcessLimitOrderValidations(t *testing.T) {
 	}
 }
 
+func TestProcessLimitOrderTimeInForce(t *testing.T) {
+	type input struct {
+		OrderID     string
+		traderID    string
+		side        orderbook.Side
+		amount      decimal.Decimal
+		price       decimal.Decimal
+		timeInForce orderbook.TimeInForce
+		expireAt    time.Time
+	}
+
+	type snapshot struct {
+		Book   *orderbook.OrderBook
+		Trades []*orderbook.Trade
+		Err    string
+	}
+
+	tests := []struct {
+		name  string
+		input input
+	}{
+		{
+			name: "ioc",
+			input: input{
+				OrderID:     "4",
+				traderID:    "4",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(5),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.IOC,
+			},
+		},
+		{
+			name: "fok filled",
+			input: input{
+				OrderID:     "4",
+				traderID:    "4",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(3),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.FOK,
+			},
+		},
+		{
+			name: "fok killed",
+			input: input{
+				OrderID:     "4",
+				traderID:    "4",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(4),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.FOK,
+			},
+		},
+		{
+			name: "fok skip same trader",
+			input: input{
+				OrderID:     "4",
+				traderID:    "3",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(3),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.FOK,
+			},
+		},
+		{
+			name: "gtd",
+			input: input{
+				OrderID:     "4",
+				traderID:    "4",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(5),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.GTD,
+				expireAt:    time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "invalid expire time",
+			input: input{
+				OrderID:     "4",
+				traderID:    "4",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(5),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.GTD,
+				expireAt:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "invalid time in force",
+			input: input{
+				OrderID:     "4",
+				traderID:    "4",
+				side:        orderbook.Buy,
+				amount:      decimal.NewFromInt(5),
+				price:       decimal.NewFromInt(400),
+				timeInForce: orderbook.TimeInForce(42),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given := []byte(`
+				{
+					"bids": [],
+					"asks": [
+						{
+							"id": "1",
+							"traderId": "1",
+							"side": "sell",
+							"amount": "5",
+							"price": "500"
+						},
+						{
+							"id": "2",
+							"traderId": "2",
+							"side": "sell",
+							"amount": "2",
+							"price": "400"
+						},
+						{
+							"id": "3",
+							"traderId": "3",
+							"side": "sell",
+							"amount": "1",
+							"price": "300"
+						}
+					]
+				}
+			`)
+
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
+			assert.Nil(t, err)
+
+			trades, err := book.ProcessLimitOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price,
+				orderbook.WithTimeInForce(tt.input.timeInForce), orderbook.WithExpireTime(tt.input.expireAt))
+
+			var errorStr string
+			if err != nil {
+				errorStr = err.Error()
+			}
+
+			s, err := json.Marshal(&snapshot{
+				Book:   book,
+				Trades: trades,
+				Err:    errorStr,
+			})
+
+			assert.Nil(t, err)
+			cupaloy.SnapshotT(t, s)
+		})
+	}
+}
+
+func TestProcessLimitOrderIceberg(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(10), decimal.NewFromInt(100), orderbook.WithDisplayAmount(decimal.NewFromInt(2)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(3), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "5", book.Depth().Asks()[0].Amount().String())
+
+	trades, err := book.ProcessLimitOrder("3", "3", orderbook.Buy, decimal.NewFromInt(6), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("4", "4", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100), orderbook.WithDisplayAmount(decimal.NewFromInt(-1)))
+	assert.Equal(t, orderbook.ErrInvalidDisplayAmount, err)
+
+	s, err := json.Marshal(&struct {
+		Book   *orderbook.OrderBook
+		Trades []*orderbook.Trade
+		Depth  *orderbook.Depth
+	}{
+		Book:   book,
+		Trades: trades,
+		Depth:  book.Depth(),
+	})
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
+
+func TestProcessLimitOrderEqualPrices(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString("100"))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString("100.0"))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("3", "3", orderbook.Sell, decimal.NewFromInt(1), decimal.RequireFromString("100.00"))
+	assert.Nil(t, err)
+
+	asks := book.Depth().Asks()
+	assert.Len(t, asks, 1)
+	assert.Equal(t, "3", asks[0].Amount().String())
+
+	assert.NotNil(t, book.CancelOrder("3"))
+
+	trades, err := book.ProcessLimitOrder("4", "4", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 2)
+	assert.Empty(t, book.Depth().Asks())
+}
+
 func benchmarkProcessLimitOrder(l int, b *testing.B) {
 	pickSide := func(j int) orderbook.Side {
 		if rand.Intn(100)%2 == 0 {
@@ -6,20 +6,43 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// ProcessMarketOrder processes a market order.
-func (ob *OrderBook) ProcessMarketOrder(orderID, traderID string, side Side, amount, price decimal.Decimal) ([]*Trade, error) {
+// ProcessMarketOrder processes a market order for an amount. The unfilled amount is cancelled.
+func (ob *OrderBook) ProcessMarketOrder(orderID, traderID string, side Side, amount decimal.Decimal, opts ...OrderOption) (*Execution, error) {
+	return ob.processMarketOrder(orderID, traderID, side, amount, decimal.Zero, false, opts)
+}
+
+// ProcessMarketOrderByFunds processes a market order spending up to the funds when buying, or receiving up to the funds when selling.
+// Each level is filled for as much as the funds left can pay, rounded down to the lot size. The unfilled funds are cancelled.
+func (ob *OrderBook) ProcessMarketOrderByFunds(orderID, traderID string, side Side, funds decimal.Decimal, opts ...OrderOption) (*Execution, error) {
+	return ob.processMarketOrder(orderID, traderID, side, decimal.Zero, funds, true, opts)
+}
+
+func (ob *OrderBook) processMarketOrder(orderID, traderID string, side Side, amount, funds decimal.Decimal, byFunds bool, opts []OrderOption) (execution *Execution, err error) {
 	defer func() {
+		if err != nil {
+			ob.emit(OrderRejected, marketOrder(orderID, traderID, side, amount, funds), nil, err)
+		}
+
 		ob.version++
 		ob.Unlock()
 	}()
 
 	ob.Lock()
 
+	commandType := MarketCommand
+	if byFunds {
+		commandType = MarketFundsCommand
+	}
+
+	if err := ob.allow(commandType); err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(orderID) == "" {
 		return nil, ErrInvalidOrderID
 	}
 
-	if ob.orders[orderID] != nil {
+	if ob.orders[orderID] != nil || ob.stops[orderID] != nil {
 		return nil, ErrOrderAlreadyExists
 	}
 
@@ -27,64 +50,175 @@ func (ob *OrderBook) ProcessMarketOrder(orderID, traderID string, side Side, amo
 		return nil, ErrInvalidTraderID
 	}
 
-	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, ErrInvalidAmount
+	if byFunds {
+		if funds.LessThanOrEqual(decimal.Zero) {
+			return nil, ErrInvalidFunds
+		}
+
+		if ob.instrument != nil && ob.instrument.minNotional.IsPositive() && funds.LessThan(ob.instrument.minNotional) {
+			return nil, ErrNotionalTooSmall
+		}
+	} else {
+		if amount.LessThanOrEqual(decimal.Zero) {
+			return nil, ErrInvalidAmount
+		}
+
+		if err := ob.instrument.ValidateAmount(amount); err != nil {
+			return nil, err
+		}
+	}
+
+	o := newOrderOptions(opts)
+
+	if !ob.selfTradePrevention(o).valid() {
+		return nil, ErrInvalidSelfTradePrevention
+	}
+
+	if err := ob.check(marketOrder(orderID, traderID, side, amount, funds)); err != nil {
+		return nil, err
 	}
 
-	if price.LessThanOrEqual(decimal.Zero) {
-		return nil, ErrInvalidPrice
+	if err := ob.record(&Command{commandType: commandType, orderID: orderID, traderID: traderID, side: side, amount: amount, funds: funds, options: o}); err != nil {
+		return nil, err
 	}
 
+	ob.emit(OrderAccepted, marketOrder(orderID, traderID, side, amount, funds), nil, nil)
+
+	execution, err = ob.processMarket(orderID, traderID, side, amount, funds, o)
+	execution.trades = ob.triggerStopOrders(execution.trades)
+
+	return execution, err
+}
+
+// processMarket matches a market order for the amount or, when the funds are positive, for the funds.
+// When a trade check fails, what is left is cancelled and the execution so far is returned with the error.
+func (ob *OrderBook) processMarket(orderID, traderID string, side Side, amount, funds decimal.Decimal, o orderOptions) (*Execution, error) {
 	var (
-		sideToProcess *OrderSide
-		level         *OrderQueue
-		next          func(decimal.Decimal) *OrderQueue
+		level *OrderQueue
+		next  func(decimal.Decimal) *OrderQueue
 	)
 
 	if side == Buy {
-		sideToProcess = ob.asks
 		level = ob.asks.MinPriceQueue()
 		next = ob.asks.GreaterThan
 	} else {
-		sideToProcess = ob.bids
 		level = ob.bids.MaxPriceQueue()
 		next = ob.bids.LessThan
 	}
 
+	byFunds := funds.IsPositive()
 	amountToTrade := amount
-	priceToTrade := price
+	fundsToSpend := funds
+	executed := decimal.Zero
+	notional := decimal.Zero
 	trades := make([]*Trade, 0)
+	stp := ob.selfTradePrevention(o)
+	taker := marketOrder(orderID, traderID, side, amount, funds)
+	lastPrice := ob.lastPrice
+	done := false
+	now := ob.now()
+
+	var err error
+
+	for level != nil && !done {
+		if !ob.band.Allows(level.price, lastPrice) {
+			ob.breach()
+			break
+		}
 
-	for level != nil && amountToTrade.GreaterThan(decimal.Zero) && priceToTrade.GreaterThan(decimal.Zero) {
 		headOrderEl := level.Front()
 
-		for headOrderEl != nil && amountToTrade.GreaterThan(decimal.Zero) && priceToTrade.GreaterThan(decimal.Zero) {
+		for headOrderEl != nil {
+			if byFunds {
+				amountToTrade = ob.affordable(fundsToSpend, level.price)
+			}
+
+			if amountToTrade.LessThanOrEqual(decimal.Zero) {
+				done = true
+				break
+			}
+
 			headOrder := headOrderEl.Value.(*Order)
 
-			if headOrder.traderID == traderID {
-				headOrderEl = headOrderEl.Next()
+			if headOrder.expired(now) {
+				headOrderEl = ob.expire(headOrderEl)
 				continue
 			}
 
-			if amount.GreaterThanOrEqual(headOrder.amount) {
-				trades = append(trades, NewTrade(orderID, headOrder.id, headOrder.amount, headOrder.price))
-				amountToTrade = amountToTrade.Sub(headOrder.amount)
-				priceToTrade = priceToTrade.Sub(headOrder.price)
+			if headOrder.traderID == traderID && stp != STPNone {
+				left := amountToTrade
+				headOrderEl, amountToTrade = ob.preventSelfTrade(stp, taker, headOrderEl, amountToTrade)
+
+				if byFunds && amountToTrade.IsZero() {
+					fundsToSpend = decimal.Zero
+					done = true
+					break
+				}
+
+				if byFunds {
+					fundsToSpend = fundsToSpend.Sub(left.Sub(amountToTrade).Mul(level.price))
+				}
 
-				headOrderEl = headOrderEl.Next()
-				ob.remove(headOrder.id)
-			} else {
-				trades = append(trades, NewTrade(orderID, headOrder.id, amountToTrade, headOrder.price))
-				sideToProcess.UpdateAmount(headOrderEl, headOrder.amount.Sub(amountToTrade))
-				amountToTrade = decimal.Zero
-				priceToTrade = decimal.Zero
+				continue
+			}
 
-				headOrderEl = headOrderEl.Next()
+			tradeAmount := decimal.Min(amountToTrade, headOrder.amount)
+
+			var trade *Trade
+			if trade, headOrderEl, err = ob.match(taker, headOrderEl, tradeAmount); err != nil {
+				done = true
+				break
+			}
+
+			if trade == nil {
+				continue
+			}
+
+			trades = append(trades, trade)
+
+			amountToTrade = amountToTrade.Sub(tradeAmount)
+			executed = executed.Add(tradeAmount)
+			notional = notional.Add(tradeAmount.Mul(trade.price))
+
+			if byFunds {
+				fundsToSpend = fundsToSpend.Sub(tradeAmount.Mul(trade.price))
 			}
 		}
 
 		level = next(level.price)
 	}
 
-	return trades, nil
+	ob.taken(taker, trades)
+
+	if byFunds {
+		if fundsToSpend.GreaterThan(decimal.Zero) {
+			ob.cancelled(marketOrder(orderID, traderID, side, decimal.Zero, fundsToSpend))
+		}
+
+		return NewExecution(trades, executed, notional, funds.Sub(notional)), err
+	}
+
+	if amountToTrade.GreaterThan(decimal.Zero) {
+		ob.cancelled(marketOrder(orderID, traderID, side, amountToTrade, decimal.Zero))
+	}
+
+	return NewExecution(trades, executed, notional, amount.Sub(executed)), err
+}
+
+// affordable returns the amount the funds pay for at the price, rounded down to the lot size.
+func (ob *OrderBook) affordable(funds, price decimal.Decimal) decimal.Decimal {
+	amount, _ := funds.QuoRem(price, int32(decimal.DivisionPrecision))
+
+	if ob.instrument != nil && ob.instrument.lotSize.IsPositive() {
+		amount = amount.Div(ob.instrument.lotSize).Floor().Mul(ob.instrument.lotSize)
+	}
+
+	return amount
+}
+
+func marketOrder(orderID, traderID string, side Side, amount, funds decimal.Decimal) *Order {
+	order := NewOrder(orderID, traderID, side, amount, decimal.Zero)
+	order.funds = funds
+
+	return order
 }
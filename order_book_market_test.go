@@ -10,25 +10,80 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestProcessMarketOrderBids(t *testing.T) {
+func TestProcessMarketOrderBuy(t *testing.T) {
 	type input struct {
 		OrderID  string
 		traderID string
 		side     orderbook.Side
 		amount   decimal.Decimal
-		price    decimal.Decimal
+		funds    decimal.Decimal
 	}
 
 	type snapshot struct {
-		Book   *orderbook.OrderBook
-		Trades []*orderbook.Trade
-		Err    string
+		Book      *orderbook.OrderBook
+		Execution *orderbook.Execution
+		Err       string
 	}
 
 	tests := []struct {
 		name  string
 		input input
-	}{}
+	}{
+		{
+			name: "fill one level",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Buy,
+				amount:   decimal.NewFromInt(1),
+			},
+		},
+		{
+			name: "fill many levels",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Buy,
+				amount:   decimal.NewFromInt(4),
+			},
+		},
+		{
+			name: "fill the whole side",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Buy,
+				amount:   decimal.NewFromInt(10),
+			},
+		},
+		{
+			name: "spend funds on one level",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Buy,
+				funds:    decimal.NewFromInt(150),
+			},
+		},
+		{
+			name: "spend funds on many levels",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Buy,
+				funds:    decimal.NewFromInt(1500),
+			},
+		},
+		{
+			name: "spend more funds than the side",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Buy,
+				funds:    decimal.NewFromInt(5000),
+			},
+		},
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -61,11 +116,16 @@ func TestProcessMarketOrderBids(t *testing.T) {
 				}
 			`)
 
-			var book orderbook.OrderBook
-			err := json.Unmarshal(given, &book)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
 			assert.Nil(t, err)
 
-			trades, err := book.ProcessMarketOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price)
+			var execution *orderbook.Execution
+			if tt.input.funds.IsZero() {
+				execution, err = book.ProcessMarketOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount)
+			} else {
+				execution, err = book.ProcessMarketOrderByFunds(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.funds)
+			}
 
 			var errorStr string
 			if err != nil {
@@ -73,9 +133,9 @@ func TestProcessMarketOrderBids(t *testing.T) {
 			}
 
 			s, err := json.Marshal(&snapshot{
-				Book:   &book,
-				Trades: trades,
-				Err:    errorStr,
+				Book:      book,
+				Execution: execution,
+				Err:       errorStr,
 			})
 
 			assert.Nil(t, err)
@@ -84,25 +144,80 @@ func TestProcessMarketOrderBids(t *testing.T) {
 	}
 }
 
-func TestProcessMarketOrderAsks(t *testing.T) {
+func TestProcessMarketOrderSell(t *testing.T) {
 	type input struct {
 		OrderID  string
 		traderID string
 		side     orderbook.Side
 		amount   decimal.Decimal
-		price    decimal.Decimal
+		funds    decimal.Decimal
 	}
 
 	type snapshot struct {
-		Book   *orderbook.OrderBook
-		Trades []*orderbook.Trade
-		Err    string
+		Book      *orderbook.OrderBook
+		Execution *orderbook.Execution
+		Err       string
 	}
 
 	tests := []struct {
 		name  string
 		input input
-	}{}
+	}{
+		{
+			name: "fill one level",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Sell,
+				amount:   decimal.NewFromInt(1),
+			},
+		},
+		{
+			name: "fill many levels",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Sell,
+				amount:   decimal.NewFromInt(4),
+			},
+		},
+		{
+			name: "fill the whole side",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Sell,
+				amount:   decimal.NewFromInt(10),
+			},
+		},
+		{
+			name: "spend funds on one level",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Sell,
+				funds:    decimal.NewFromInt(150),
+			},
+		},
+		{
+			name: "spend funds on many levels",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Sell,
+				funds:    decimal.NewFromInt(1500),
+			},
+		},
+		{
+			name: "spend more funds than the side",
+			input: input{
+				OrderID:  "4",
+				traderID: "4",
+				side:     orderbook.Sell,
+				funds:    decimal.NewFromInt(5000),
+			},
+		},
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -135,11 +250,16 @@ func TestProcessMarketOrderAsks(t *testing.T) {
 				}
 			`)
 
-			var book orderbook.OrderBook
-			err := json.Unmarshal(given, &book)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
 			assert.Nil(t, err)
 
-			trades, err := book.ProcessMarketOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price)
+			var execution *orderbook.Execution
+			if tt.input.funds.IsZero() {
+				execution, err = book.ProcessMarketOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount)
+			} else {
+				execution, err = book.ProcessMarketOrderByFunds(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.funds)
+			}
 
 			var errorStr string
 			if err != nil {
@@ -147,9 +267,9 @@ func TestProcessMarketOrderAsks(t *testing.T) {
 			}
 
 			s, err := json.Marshal(&snapshot{
-				Book:   &book,
-				Trades: trades,
-				Err:    errorStr,
+				Book:      book,
+				Execution: execution,
+				Err:       errorStr,
 			})
 
 			assert.Nil(t, err)
@@ -164,13 +284,13 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 		traderID string
 		side     orderbook.Side
 		amount   decimal.Decimal
-		price    decimal.Decimal
+		funds    decimal.Decimal
 	}
 
 	type snapshot struct {
-		Book   *orderbook.OrderBook
-		Trades []*orderbook.Trade
-		Err    string
+		Book      *orderbook.OrderBook
+		Execution *orderbook.Execution
+		Err       string
 	}
 
 	tests := []struct {
@@ -184,7 +304,6 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 				traderID: "4",
 				side:     orderbook.Sell,
 				amount:   decimal.NewFromInt(5),
-				price:    decimal.NewFromInt(550),
 			},
 		},
 		{
@@ -194,7 +313,6 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 				traderID: "4",
 				side:     orderbook.Sell,
 				amount:   decimal.NewFromInt(5),
-				price:    decimal.NewFromInt(550),
 			},
 		},
 		{
@@ -204,7 +322,6 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 				traderID: "",
 				side:     orderbook.Sell,
 				amount:   decimal.NewFromInt(5),
-				price:    decimal.NewFromInt(550),
 			},
 		},
 		{
@@ -214,17 +331,15 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 				traderID: "4",
 				side:     orderbook.Sell,
 				amount:   decimal.NewFromInt(0),
-				price:    decimal.NewFromInt(550),
 			},
 		},
 		{
-			name: "invalid price",
+			name: "invalid funds",
 			input: input{
 				OrderID:  "4",
 				traderID: "4",
 				side:     orderbook.Sell,
-				amount:   decimal.NewFromInt(5),
-				price:    decimal.NewFromInt(0),
+				funds:    decimal.NewFromInt(-1),
 			},
 		},
 	}
@@ -260,11 +375,16 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 				}
 			`)
 
-			var book orderbook.OrderBook
-			err := json.Unmarshal(given, &book)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
 			assert.Nil(t, err)
 
-			trades, err := book.ProcessMarketOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price)
+			var execution *orderbook.Execution
+			if tt.input.funds.IsZero() {
+				execution, err = book.ProcessMarketOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount)
+			} else {
+				execution, err = book.ProcessMarketOrderByFunds(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.funds)
+			}
 
 			var errorStr string
 			if err != nil {
@@ -272,9 +392,9 @@ func TestProcessMarketOrderValidations(t *testing.T) {
 			}
 
 			s, err := json.Marshal(&snapshot{
-				Book:   &book,
-				Trades: trades,
-				Err:    errorStr,
+				Book:      book,
+				Execution: execution,
+				Err:       errorStr,
 			})
 
 			assert.Nil(t, err)
@@ -0,0 +1,64 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// OrderDepth returns every resting order, best prices first and in time priority within a price.
+func (ob *OrderBook) OrderDepth() *OrderDepth {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	entries := func(orders []*Order) []*OrderEntry {
+		entries := make([]*OrderEntry, 0, len(orders))
+		for _, order := range orders {
+			entries = append(entries, NewOrderEntry(order.id, ob.tag(order.traderID), order.price, order.amount))
+		}
+
+		return entries
+	}
+
+	return NewOrderDepth(entries(ob.bids.Orders()), entries(ob.asks.Orders()), ob.version)
+}
+
+// SubscribeOrders registers a listener for the resting order changes, which keep an OrderDepth snapshot up to date.
+func (ob *OrderBook) SubscribeOrders(listener OrderListener) {
+	defer ob.Unlock()
+	ob.Lock()
+
+	ob.orderListeners = append(ob.orderListeners, listener)
+}
+
+// orderChanged reports a change of a resting order. Changes made while matching are executions.
+func (ob *OrderBook) orderChanged(updateType OrderUpdateType, order *Order, previous decimal.Decimal) {
+	if len(ob.orderListeners) == 0 {
+		return
+	}
+
+	amount, executed := order.amount, decimal.Zero
+
+	switch updateType {
+	case OrderModified:
+		if ob.executing {
+			updateType, executed = OrderExecuted, previous.Sub(order.amount)
+		}
+	case OrderDeleted:
+		amount = decimal.Zero
+		if ob.executing {
+			updateType, executed = OrderExecuted, previous
+		}
+	}
+
+	update := NewOrderUpdate(updateType, order.id, ob.tag(order.traderID), order.side, order.price, amount, executed, ob.version+1)
+	for _, listener := range ob.orderListeners {
+		listener.OnOrderUpdate(update)
+	}
+}
+
+func (ob *OrderBook) tag(traderID string) string {
+	if ob.traderTag == nil {
+		return ""
+	}
+
+	return ob.traderTag(traderID)
+}
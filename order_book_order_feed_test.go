@@ -0,0 +1,88 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderDepth(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithTraderTags(orderbook.HashTraderTag("salt")))
+
+	for _, id := range []string{"1", "2", "3", "4", "5", "6", "7", "8"} {
+		_, err := book.ProcessPostOnlyOrder(id, "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+		assert.Nil(t, err)
+	}
+
+	_, err := book.ProcessPostOnlyOrder("9", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(99))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessPostOnlyOrder("10", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	depth := book.OrderDepth()
+	ids := make([]string, 0)
+	for _, entry := range depth.Asks() {
+		ids = append(ids, entry.OrderID())
+	}
+
+	assert.Equal(t, []string{"9", "1", "2", "3", "4", "5", "6", "7", "8"}, ids)
+	assert.Equal(t, orderbook.HashTraderTag("salt")("2"), depth.Bids()[0].TraderTag())
+	assert.NotEqual(t, depth.Asks()[0].TraderTag(), depth.Asks()[1].TraderTag())
+	assert.Equal(t, book.Version(), depth.Version())
+
+	s, err := json.Marshal(depth)
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
+
+func TestOrderUpdates(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessPostOnlyOrder("1", "1", orderbook.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	snapshot := book.OrderDepth()
+
+	updates := make([]*orderbook.OrderUpdate, 0)
+	book.SubscribeOrders(orderbook.OrderListenerFunc(func(update *orderbook.OrderUpdate) {
+		updates = append(updates, update)
+	}))
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(5), decimal.NewFromInt(100), orderbook.WithDisplayAmount(decimal.NewFromInt(2)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessPostOnlyOrder("3", "3", orderbook.Sell, decimal.NewFromInt(3), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("4", "4", orderbook.Buy, decimal.NewFromInt(5), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.AmendOrder("3", decimal.NewFromInt(1), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, book.CancelOrder("3"))
+
+	orders := map[string]string{}
+	for _, entry := range snapshot.Asks() {
+		orders[entry.OrderID()] = entry.Amount().String()
+	}
+
+	for _, update := range updates {
+		assert.Greater(t, update.Version(), snapshot.Version())
+		orders[update.OrderID()] = update.Amount().String()
+	}
+
+	assert.Equal(t, map[string]string{"1": "0", "2": "1", "3": "0"}, orders)
+	assert.Equal(t, "1", book.OrderDepth().Asks()[0].Amount().String())
+
+	s, err := json.Marshal(updates)
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
@@ -6,20 +6,28 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// ProcessPostOnlyOrder processes a post only order.
-func (ob *OrderBook) ProcessPostOnlyOrder(orderID, traderID string, side Side, amount, price decimal.Decimal) ([]*Trade, error) {
+// ProcessPostOnlyOrder processes a post only order. It is rejected when it would cross the book, except in an auction.
+func (ob *OrderBook) ProcessPostOnlyOrder(orderID, traderID string, side Side, amount, price decimal.Decimal) (trades []*Trade, err error) {
 	defer func() {
+		if err != nil {
+			ob.emit(OrderRejected, NewOrder(orderID, traderID, side, amount, price), nil, err)
+		}
+
 		ob.version++
 		ob.Unlock()
 	}()
 
 	ob.Lock()
 
+	if err := ob.allow(PostOnlyCommand); err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(orderID) == "" {
 		return nil, ErrInvalidOrderID
 	}
 
-	if ob.orders[orderID] != nil {
+	if ob.orders[orderID] != nil || ob.stops[orderID] != nil {
 		return nil, ErrOrderAlreadyExists
 	}
 
@@ -35,7 +43,24 @@ func (ob *OrderBook) ProcessPostOnlyOrder(orderID, traderID string, side Side, a
 		return nil, ErrInvalidPrice
 	}
 
+	if err := ob.instrument.Validate(amount, price); err != nil {
+		return nil, err
+	}
+
+	if ob.status != Auction && ob.crosses(side, price) {
+		return nil, ErrPostOnlyWouldCross
+	}
+
+	if err := ob.check(NewOrder(orderID, traderID, side, amount, price)); err != nil {
+		return nil, err
+	}
+
+	if err := ob.record(&Command{commandType: PostOnlyCommand, orderID: orderID, traderID: traderID, side: side, amount: amount, price: price}); err != nil {
+		return nil, err
+	}
+
 	order := NewOrder(orderID, traderID, side, amount, price)
+	ob.emit(OrderAccepted, order.clone(), nil, nil)
 
 	if side == Buy {
 		ob.orders[order.id] = ob.bids.Append(order)
@@ -43,5 +68,9 @@ func (ob *OrderBook) ProcessPostOnlyOrder(orderID, traderID string, side Side, a
 		ob.orders[order.id] = ob.asks.Append(order)
 	}
 
+	ob.traders[order.traderID]++
+
+	ob.emit(OrderRested, order.clone(), nil, nil)
+
 	return make([]*Trade, 0), nil
 }
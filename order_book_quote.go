@@ -6,7 +6,8 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// Quote quotes a market order.
+// Quote quotes a market order. Resting orders of the same trader are handled by the book self trade prevention mode,
+// and the hidden reserve of iceberg orders is quoted at their price.
 func (ob *OrderBook) Quote(traderID string, side Side, amount decimal.Decimal) (*Quote, error) {
 	defer ob.RUnlock()
 	ob.RLock()
@@ -39,15 +40,26 @@ func (ob *OrderBook) Quote(traderID string, side Side, amount decimal.Decimal) (
 
 		for headOrderEl != nil && amount.GreaterThan(decimal.Zero) {
 			headOrder := headOrderEl.Value.(*Order)
+			available := headOrder.amount.Add(headOrder.hiddenAmount)
 
 			if headOrder.traderID == traderID {
-				headOrderEl = headOrderEl.Next()
-				continue
+				switch ob.stp {
+				case STPNone:
+				case STPCancelNewest, STPCancelBoth:
+					return NewQuote(price, amount), nil
+				case STPDecrementAndCancel:
+					amount = decimal.Max(amount.Sub(available), decimal.Zero)
+					headOrderEl = headOrderEl.Next()
+					continue
+				default:
+					headOrderEl = headOrderEl.Next()
+					continue
+				}
 			}
 
-			if amount.GreaterThanOrEqual(headOrder.amount) {
-				price = price.Add(headOrder.price.Mul(headOrder.amount))
-				amount = amount.Sub(headOrder.amount)
+			if amount.GreaterThanOrEqual(available) {
+				price = price.Add(headOrder.price.Mul(available))
+				amount = amount.Sub(available)
 			} else {
 				price = price.Add(headOrder.price.Mul(amount))
 				amount = decimal.Zero
@@ -308,3 +308,22 @@ func TestQuoteValidations(t *testing.T) {
 		})
 	}
 }
+
+func TestQuoteIceberg(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(10), decimal.NewFromInt(100), orderbook.WithDisplayAmount(decimal.NewFromInt(2)))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Sell, decimal.NewFromInt(5), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+
+	quote, err := book.Quote("3", orderbook.Buy, decimal.NewFromInt(12))
+	assert.Nil(t, err)
+	assert.Equal(t, "1220", quote.Price().String())
+	assert.Equal(t, "0", quote.RemainingAmount().String())
+
+	execution, err := book.ProcessMarketOrder("3", "3", orderbook.Buy, decimal.NewFromInt(12))
+	assert.Nil(t, err)
+	assert.Equal(t, quote.Price().String(), execution.Notional().String())
+}
@@ -0,0 +1,94 @@
+package orderbook
+
+import "github.com/shopspring/decimal"
+
+// check runs the risk checks on an order. Journaled commands passed them already, so they are not checked again while replaying.
+func (ob *OrderBook) check(order *Order) error {
+	if ob.replaying {
+		return nil
+	}
+
+	view := bookView{ob}
+	for _, check := range ob.riskChecks {
+		if err := check.Check(order, view); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkTrade runs the trade checks on a trade about to execute. Unlike the risk checks they also run while replaying,
+// as where the matching of a journaled command stopped depends on them.
+func (ob *OrderBook) checkTrade(trade *Trade) error {
+	for _, check := range ob.riskChecks {
+		if check, ok := check.(TradeCheck); ok {
+			if err := check.CheckTrade(trade); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// untrack counts a resting or stop order of the trader out.
+func (ob *OrderBook) untrack(traderID string) {
+	ob.traders[traderID]--
+	if ob.traders[traderID] <= 0 {
+		delete(ob.traders, traderID)
+	}
+}
+
+// bookView is the view of a locked book.
+type bookView struct {
+	ob *OrderBook
+}
+
+func (v bookView) Symbol() string {
+	return v.ob.symbol
+}
+
+func (v bookView) Status() TradingStatus {
+	return v.ob.status
+}
+
+func (v bookView) LastPrice() decimal.Decimal {
+	return v.ob.lastPrice
+}
+
+func (v bookView) BestBid() decimal.Decimal {
+	if q := v.ob.bids.MaxPriceQueue(); q != nil {
+		return q.price
+	}
+
+	return decimal.Zero
+}
+
+func (v bookView) BestAsk() decimal.Decimal {
+	if q := v.ob.asks.MinPriceQueue(); q != nil {
+		return q.price
+	}
+
+	return decimal.Zero
+}
+
+func (v bookView) Depth() *Depth {
+	return v.ob.depth(depthOptions{})
+}
+
+func (v bookView) Order(orderID string) *Order {
+	if e, ok := v.ob.orders[orderID]; ok {
+		return e.Value.(*Order).clone()
+	}
+
+	if e, ok := v.ob.stops[orderID]; ok {
+		return e.Value.(*StopOrder).order.clone()
+	}
+
+	return nil
+}
+
+func (v bookView) OpenOrders(traderID string) int {
+	return v.ob.traders[traderID]
+}
@@ -0,0 +1,86 @@
+package orderbook_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskChecks(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithRiskChecks(
+		orderbook.MaxAmount(decimal.NewFromInt(10)),
+		orderbook.MaxNotional(decimal.NewFromInt(1000)),
+		orderbook.MaxPriceDistance(decimal.RequireFromString("0.1")),
+		orderbook.MaxOpenOrders(2),
+	))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(11), decimal.NewFromInt(1))
+	assert.Equal(t, orderbook.ErrMaxAmountExceeded, err)
+
+	_, err = book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(10), decimal.NewFromInt(101))
+	assert.Equal(t, orderbook.ErrMaxNotionalExceeded, err)
+
+	_, err = book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessPostOnlyOrder("2", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(89))
+	assert.Equal(t, orderbook.ErrPriceTooFar, err)
+
+	_, err = book.ProcessPostOnlyOrder("2", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessMarketOrder("3", "3", orderbook.Buy, decimal.NewFromInt(11))
+	assert.Equal(t, orderbook.ErrMaxAmountExceeded, err)
+
+	_, err = book.ProcessMarketOrderByFunds("3", "3", orderbook.Buy, decimal.NewFromInt(1001))
+	assert.Equal(t, orderbook.ErrMaxNotionalExceeded, err)
+
+	_, err = book.ProcessStopOrder("3", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("4", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(91))
+	assert.Equal(t, orderbook.ErrTooManyOpenOrders, err)
+
+	_, err = book.AmendOrder("2", decimal.NewFromInt(2), decimal.NewFromInt(91))
+	assert.Nil(t, err)
+
+	_, err = book.AmendOrder("2", decimal.NewFromInt(20), decimal.NewFromInt(91))
+	assert.Equal(t, orderbook.ErrMaxAmountExceeded, err)
+
+	assert.NotNil(t, book.CancelOrder("3"))
+
+	_, err = book.ProcessLimitOrder("4", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(91))
+	assert.Nil(t, err)
+}
+
+func TestRiskCheckFunc(t *testing.T) {
+	errBlocked := errors.New("Blocked")
+
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithRiskChecks(orderbook.RiskCheckFunc(func(order *orderbook.Order, view orderbook.BookView) error {
+		assert.Equal(t, "BTC/USD", view.Symbol())
+
+		if order.TraderID() == "blocked" {
+			return errBlocked
+		}
+
+		return nil
+	})))
+
+	var rejected *orderbook.Event
+	book.Subscribe(orderbook.ListenerFunc(func(event *orderbook.Event) {
+		if event.Type() == orderbook.OrderRejected {
+			rejected = event
+		}
+	}))
+
+	_, err := book.ProcessLimitOrder("1", "blocked", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Equal(t, errBlocked, err)
+	assert.NotNil(t, rejected)
+	assert.Equal(t, errBlocked, rejected.Err())
+
+	_, err = book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,123 @@
+package orderbook
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Status returns the trading status.
+func (ob *OrderBook) Status() TradingStatus {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return ob.status
+}
+
+// SetStatus moves the book to another trading status.
+// Any status can be halted or closed. An auction can only be left to open the market by Uncross,
+// and the market can not be opened while the book is crossed.
+func (ob *OrderBook) SetStatus(status TradingStatus) error {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	return ob.setStatus(status)
+}
+
+// Halt freezes the market.
+func (ob *OrderBook) Halt() error {
+	return ob.SetStatus(Halted)
+}
+
+// StartAuction starts a call auction. Limit orders only accumulate in the book until Uncross is called.
+func (ob *OrderBook) StartAuction() error {
+	defer func() {
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	if ob.status == Auction {
+		return ErrAuctionInProgress
+	}
+
+	return ob.setStatus(Auction)
+}
+
+func (ob *OrderBook) setStatus(status TradingStatus) error {
+	if !status.valid() {
+		return ErrInvalidStatusTransition
+	}
+
+	if status == ob.status {
+		return nil
+	}
+
+	if status == Open && (ob.status == Auction || ob.crossed()) {
+		return ErrInvalidStatusTransition
+	}
+
+	if ob.status == Auction && status != Halted && status != Closed {
+		return ErrInvalidStatusTransition
+	}
+
+	if err := ob.record(&Command{commandType: StatusCommand, status: status}); err != nil {
+		return err
+	}
+
+	ob.status = status
+	ob.resumeAt = time.Time{}
+	return nil
+}
+
+// crosses tells if an order at the price would reach the best price of the other side.
+func (ob *OrderBook) crosses(side Side, price decimal.Decimal) bool {
+	if side == Buy {
+		asks := ob.asks.MinPriceQueue()
+		return asks != nil && price.GreaterThanOrEqual(asks.price)
+	}
+
+	bids := ob.bids.MaxPriceQueue()
+	return bids != nil && price.LessThanOrEqual(bids.price)
+}
+
+// crossed tells if the best bid reaches the best ask.
+func (ob *OrderBook) crossed() bool {
+	bids := ob.bids.MaxPriceQueue()
+	asks := ob.asks.MinPriceQueue()
+
+	return bids != nil && asks != nil && bids.price.GreaterThanOrEqual(asks.price)
+}
+
+// allow checks the trading status accepts a command, once a market halted by the circuit breaker is resumed.
+func (ob *OrderBook) allow(commandType CommandType) error {
+	ob.resume()
+
+	switch ob.status {
+	case Halted:
+		return ErrMarketHalted
+	case Closed:
+		if commandType != CancelCommand {
+			return ErrMarketClosed
+		}
+	case CancelOnly:
+		if commandType != CancelCommand {
+			return ErrCancelOnly
+		}
+	case PostOnlyOnly:
+		if commandType != CancelCommand && commandType != PostOnlyCommand {
+			return ErrPostOnlyOnly
+		}
+	case Auction:
+		if commandType == MarketCommand || commandType == MarketFundsCommand {
+			return ErrAuctionInProgress
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package orderbook_test
+
+import (
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradingStatus(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD")
+	assert.Equal(t, orderbook.Open, book.Status())
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		status   orderbook.TradingStatus
+		limit    error
+		market   error
+		postOnly error
+	}{
+		{orderbook.Halted, orderbook.ErrMarketHalted, orderbook.ErrMarketHalted, orderbook.ErrMarketHalted},
+		{orderbook.CancelOnly, orderbook.ErrCancelOnly, orderbook.ErrCancelOnly, orderbook.ErrCancelOnly},
+		{orderbook.PostOnlyOnly, orderbook.ErrPostOnlyOnly, orderbook.ErrPostOnlyOnly, nil},
+		{orderbook.Closed, orderbook.ErrMarketClosed, orderbook.ErrMarketClosed, orderbook.ErrMarketClosed},
+	}
+
+	for _, tt := range tests {
+		assert.Nil(t, book.SetStatus(tt.status))
+		assert.Equal(t, tt.status, book.Status())
+
+		_, err = book.ProcessLimitOrder("3", "3", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+		assert.Equal(t, tt.limit, err)
+
+		_, err = book.ProcessMarketOrder("3", "3", orderbook.Buy, decimal.NewFromInt(1))
+		assert.Equal(t, tt.market, err)
+
+		_, err = book.ProcessPostOnlyOrder("3", "3", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(80))
+		assert.Equal(t, tt.postOnly, err)
+
+		if tt.postOnly == nil {
+			assert.NotNil(t, book.CancelOrder("3"))
+		}
+	}
+
+	assert.Nil(t, book.Halt())
+	assert.Nil(t, book.CancelOrder("1"))
+
+	order, err := book.CancelOrderErr("1")
+	assert.Nil(t, order)
+	assert.Equal(t, orderbook.ErrMarketHalted, err)
+
+	assert.Nil(t, book.SetStatus(orderbook.CancelOnly))
+	assert.NotNil(t, book.CancelOrder("1"))
+
+	assert.Nil(t, book.SetStatus(orderbook.Open))
+	assert.Equal(t, orderbook.ErrInvalidStatusTransition, book.SetStatus(orderbook.TradingStatus(99)))
+}
+
+func TestTradingStatusAuction(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD")
+
+	assert.Nil(t, book.StartAuction())
+	assert.Equal(t, orderbook.ErrInvalidStatusTransition, book.SetStatus(orderbook.Open))
+	assert.Equal(t, orderbook.ErrInvalidStatusTransition, book.SetStatus(orderbook.CancelOnly))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+
+	assert.Nil(t, book.Halt())
+	assert.Equal(t, orderbook.ErrInvalidStatusTransition, book.SetStatus(orderbook.Open))
+
+	assert.Nil(t, book.StartAuction())
+
+	trades, err := book.Uncross()
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, orderbook.Open, book.Status())
+}
+
+func TestPostOnlyOnlyCrossing(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	assert.Nil(t, book.SetStatus(orderbook.PostOnlyOnly))
+
+	_, err = book.ProcessPostOnlyOrder("2", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Equal(t, orderbook.ErrPostOnlyWouldCross, err)
+
+	_, err = book.ProcessPostOnlyOrder("3", "2", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+
+	assert.Nil(t, book.SetStatus(orderbook.Open))
+}
@@ -0,0 +1,158 @@
+package orderbook
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProcessStopOrder processes a stop order. The order is parked until a trade reaches the stop price and is then fired as a market order.
+func (ob *OrderBook) ProcessStopOrder(orderID, traderID string, side Side, amount, stopPrice decimal.Decimal) ([]*Trade, error) {
+	return ob.processStopOrder(orderID, traderID, side, amount, decimal.Zero, stopPrice, false)
+}
+
+// ProcessStopLimitOrder processes a stop limit order. The order is parked until a trade reaches the stop price and is then fired as a limit order.
+func (ob *OrderBook) ProcessStopLimitOrder(orderID, traderID string, side Side, amount, price, stopPrice decimal.Decimal) ([]*Trade, error) {
+	return ob.processStopOrder(orderID, traderID, side, amount, price, stopPrice, true)
+}
+
+func (ob *OrderBook) processStopOrder(orderID, traderID string, side Side, amount, price, stopPrice decimal.Decimal, limit bool) (trades []*Trade, err error) {
+	defer func() {
+		if err != nil {
+			ob.emit(OrderRejected, NewOrder(orderID, traderID, side, amount, price), nil, err)
+		}
+
+		ob.version++
+		ob.Unlock()
+	}()
+
+	ob.Lock()
+
+	commandType := StopCommand
+	if limit {
+		commandType = StopLimitCommand
+	}
+
+	if err := ob.allow(commandType); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(orderID) == "" {
+		return nil, ErrInvalidOrderID
+	}
+
+	if ob.orders[orderID] != nil || ob.stops[orderID] != nil {
+		return nil, ErrOrderAlreadyExists
+	}
+
+	if strings.TrimSpace(traderID) == "" {
+		return nil, ErrInvalidTraderID
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	if limit && price.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidPrice
+	}
+
+	if stopPrice.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidStopPrice
+	}
+
+	if limit {
+		if err := ob.instrument.Validate(amount, price); err != nil {
+			return nil, err
+		}
+	} else if err := ob.instrument.ValidateAmount(amount); err != nil {
+		return nil, err
+	}
+
+	if err := ob.instrument.ValidatePrice(stopPrice); err != nil {
+		return nil, err
+	}
+
+	if err := ob.check(NewOrder(orderID, traderID, side, amount, price)); err != nil {
+		return nil, err
+	}
+
+	if err := ob.record(&Command{commandType: commandType, orderID: orderID, traderID: traderID, side: side, amount: amount, price: price, stopPrice: stopPrice}); err != nil {
+		return nil, err
+	}
+
+	stop := NewStopOrder(NewOrder(orderID, traderID, side, amount, price), stopPrice, limit)
+	ob.emit(OrderAccepted, stop.order.clone(), nil, nil)
+
+	if side == Buy {
+		ob.stops[orderID] = ob.stopBuys.Append(stop)
+	} else {
+		ob.stops[orderID] = ob.stopSells.Append(stop)
+	}
+
+	ob.traders[traderID]++
+
+	return ob.triggerStopOrders(make([]*Trade, 0)), nil
+}
+
+// triggerStopOrders fires every stop order reached by the trades, buy stops by the highest traded price and sell stops
+// by the lowest, or by the last traded price when nothing traded.
+// Fired orders may trade and reach other stop prices, so it cascades until no stop order is triggered.
+func (ob *OrderBook) triggerStopOrders(trades []*Trade) []*Trade {
+	low, high := ob.lastPrice, ob.lastPrice
+	if len(trades) > 0 {
+		ob.lastPrice = trades[len(trades)-1].price
+		low, high = priceRange(trades)
+	}
+
+	for ob.status == Open && !high.IsZero() {
+		e := ob.stopBuys.Triggered(high)
+		if e == nil {
+			e = ob.stopSells.Triggered(low)
+		}
+
+		if e == nil {
+			break
+		}
+
+		stop := ob.removeStop(e.Value.(*StopOrder).order.id)
+		order := stop.order
+
+		var (
+			fired []*Trade
+			err   error
+		)
+
+		if stop.limit {
+			fired, err = ob.processLimit(order.id, order.traderID, order.side, order.amount, order.price, orderOptions{})
+		} else {
+			var execution *Execution
+			execution, err = ob.processMarket(order.id, order.traderID, order.side, order.amount, decimal.Zero, orderOptions{})
+			fired = execution.trades
+		}
+
+		if err != nil {
+			ob.emit(OrderRejected, order.clone(), nil, err)
+		}
+
+		if len(fired) > 0 {
+			ob.lastPrice = fired[len(fired)-1].price
+			l, h := priceRange(fired)
+			low, high = decimal.Min(low, l), decimal.Max(high, h)
+		}
+
+		trades = append(trades, fired...)
+	}
+
+	return trades
+}
+
+// priceRange returns the lowest and the highest price of the trades.
+func priceRange(trades []*Trade) (decimal.Decimal, decimal.Decimal) {
+	low, high := trades[0].price, trades[0].price
+	for _, trade := range trades[1:] {
+		low, high = decimal.Min(low, trade.price), decimal.Max(high, trade.price)
+	}
+
+	return low, high
+}
@@ -0,0 +1,263 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessStopOrder(t *testing.T) {
+	type input struct {
+		OrderID   string
+		traderID  string
+		side      orderbook.Side
+		amount    decimal.Decimal
+		price     decimal.Decimal
+		stopPrice decimal.Decimal
+		limit     bool
+	}
+
+	type snapshot struct {
+		Book   *orderbook.OrderBook
+		Trades []*orderbook.Trade
+		Err    string
+	}
+
+	tests := []struct {
+		name  string
+		input input
+	}{
+		{
+			name: "parked buy stop",
+			input: input{
+				OrderID:   "5",
+				traderID:  "5",
+				side:      orderbook.Buy,
+				amount:    decimal.NewFromInt(1),
+				price:     decimal.NewFromInt(1000),
+				stopPrice: decimal.NewFromInt(500),
+			},
+		},
+		{
+			name: "parked sell stop limit",
+			input: input{
+				OrderID:   "5",
+				traderID:  "5",
+				side:      orderbook.Sell,
+				amount:    decimal.NewFromInt(1),
+				price:     decimal.NewFromInt(150),
+				stopPrice: decimal.NewFromInt(200),
+				limit:     true,
+			},
+		},
+		{
+			name: "triggered on submission",
+			input: input{
+				OrderID:   "5",
+				traderID:  "5",
+				side:      orderbook.Buy,
+				amount:    decimal.NewFromInt(1),
+				price:     decimal.NewFromInt(1000),
+				stopPrice: decimal.NewFromInt(300),
+			},
+		},
+		{
+			name: "order already exists",
+			input: input{
+				OrderID:   "1",
+				traderID:  "5",
+				side:      orderbook.Buy,
+				amount:    decimal.NewFromInt(1),
+				price:     decimal.NewFromInt(1000),
+				stopPrice: decimal.NewFromInt(500),
+			},
+		},
+		{
+			name: "invalid stop price",
+			input: input{
+				OrderID:   "5",
+				traderID:  "5",
+				side:      orderbook.Buy,
+				amount:    decimal.NewFromInt(1),
+				price:     decimal.NewFromInt(1000),
+				stopPrice: decimal.NewFromInt(0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given := []byte(`
+				{
+					"bids": [],
+					"asks": [
+						{
+							"id": "1",
+							"traderId": "1",
+							"side": "sell",
+							"amount": "5",
+							"price": "500"
+						},
+						{
+							"id": "2",
+							"traderId": "2",
+							"side": "sell",
+							"amount": "2",
+							"price": "400"
+						}
+					],
+					"lastPrice": "300"
+				}
+			`)
+
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+			err := json.Unmarshal(given, book)
+			assert.Nil(t, err)
+
+			var trades []*orderbook.Trade
+			if tt.input.limit {
+				trades, err = book.ProcessStopLimitOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.price, tt.input.stopPrice)
+			} else {
+				trades, err = book.ProcessStopOrder(tt.input.OrderID, tt.input.traderID, tt.input.side, tt.input.amount, tt.input.stopPrice)
+			}
+
+			var errorStr string
+			if err != nil {
+				errorStr = err.Error()
+			}
+
+			s, err := json.Marshal(&snapshot{
+				Book:   book,
+				Trades: trades,
+				Err:    errorStr,
+			})
+
+			assert.Nil(t, err)
+			cupaloy.SnapshotT(t, s)
+		})
+	}
+}
+
+func TestTriggerStopOrders(t *testing.T) {
+	given := []byte(`
+		{
+			"bids": [],
+			"asks": [
+				{
+					"id": "1",
+					"traderId": "1",
+					"side": "sell",
+					"amount": "1",
+					"price": "300"
+				},
+				{
+					"id": "2",
+					"traderId": "2",
+					"side": "sell",
+					"amount": "1",
+					"price": "400"
+				},
+				{
+					"id": "3",
+					"traderId": "3",
+					"side": "sell",
+					"amount": "5",
+					"price": "500"
+				}
+			],
+			"stops": [
+				{
+					"order": {
+						"id": "4",
+						"traderId": "4",
+						"side": "buy",
+						"amount": "1",
+						"price": "450"
+					},
+					"stopPrice": "300",
+					"limit": true
+				},
+				{
+					"order": {
+						"id": "5",
+						"traderId": "5",
+						"side": "buy",
+						"amount": "2",
+						"price": "10000"
+					},
+					"stopPrice": "400",
+					"limit": false
+				},
+				{
+					"order": {
+						"id": "6",
+						"traderId": "6",
+						"side": "sell",
+						"amount": "1",
+						"price": "100"
+					},
+					"stopPrice": "200",
+					"limit": true
+				}
+			]
+		}
+	`)
+
+	book := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+	err := json.Unmarshal(given, book)
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("7", "7", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(300))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 3)
+
+	s, err := json.Marshal(&struct {
+		Book   *orderbook.OrderBook
+		Trades []*orderbook.Trade
+	}{
+		Book:   book,
+		Trades: trades,
+	})
+
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, s)
+}
+
+func TestCancelStopOrder(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD")
+
+	_, err := book.ProcessStopOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(90))
+	assert.Nil(t, err)
+
+	order := book.CancelOrder("1")
+	assert.NotNil(t, order)
+	assert.Equal(t, "1", order.ID())
+	assert.Nil(t, book.CancelOrder("1"))
+}
+
+func TestTriggerStopOrdersPriceRange(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(110))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "1", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("3", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(120))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessStopOrder("4", "2", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(105))
+	assert.Nil(t, err)
+
+	execution, err := book.ProcessMarketOrder("5", "3", orderbook.Sell, decimal.NewFromInt(2))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 3)
+	assert.Equal(t, "4", execution.Trades()[2].TakerOrderID())
+	assert.Equal(t, "120", execution.Trades()[2].Price().String())
+	assert.Empty(t, book.OpenOrders("2"))
+}
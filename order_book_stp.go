@@ -0,0 +1,74 @@
+package orderbook
+
+import (
+	"container/list"
+
+	"github.com/shopspring/decimal"
+)
+
+// selfTradePrevention returns the mode of the order, falling back to the book default.
+func (ob *OrderBook) selfTradePrevention(o orderOptions) SelfTradePrevention {
+	if o.selfTradePrevention != nil {
+		return *o.selfTradePrevention
+	}
+
+	return ob.stp
+}
+
+// preventSelfTrade applies the mode when the taker meets the resting order e of the same trader.
+// It returns the next resting order to match and the amount left to the taker.
+func (ob *OrderBook) preventSelfTrade(mode SelfTradePrevention, taker *Order, e *list.Element, amountToTrade decimal.Decimal) (*list.Element, decimal.Decimal) {
+	maker := e.Value.(*Order)
+
+	switch mode {
+	case STPCancelNewest:
+		ob.cancelled(NewOrder(taker.id, taker.traderID, taker.side, amountToTrade, taker.price))
+		return e.Next(), decimal.Zero
+
+	case STPCancelOldest:
+		next := e.Next()
+		ob.cancelled(ob.remove(maker.id))
+		return next, amountToTrade
+
+	case STPCancelBoth:
+		next := e.Next()
+		ob.cancelled(ob.remove(maker.id))
+		ob.cancelled(NewOrder(taker.id, taker.traderID, taker.side, amountToTrade, taker.price))
+		return next, decimal.Zero
+
+	case STPDecrementAndCancel:
+		total := maker.amount.Add(maker.hiddenAmount)
+
+		if amountToTrade.LessThan(total) {
+			hidden := decimal.Min(maker.hiddenAmount, amountToTrade)
+			maker.hiddenAmount = maker.hiddenAmount.Sub(hidden)
+
+			if visible := amountToTrade.Sub(hidden); visible.IsPositive() {
+				ob.sideOf(maker).UpdateAmount(e, maker.amount.Sub(visible))
+			}
+
+			ob.cancelled(NewOrder(taker.id, taker.traderID, taker.side, amountToTrade, taker.price))
+			return e.Next(), decimal.Zero
+		}
+
+		next := e.Next()
+		ob.cancelled(ob.remove(maker.id))
+
+		if amountToTrade.Equal(total) {
+			ob.cancelled(NewOrder(taker.id, taker.traderID, taker.side, amountToTrade, taker.price))
+		}
+
+		return next, amountToTrade.Sub(total)
+
+	default:
+		return e.Next(), amountToTrade
+	}
+}
+
+func (ob *OrderBook) sideOf(order *Order) *OrderSide {
+	if order.side == Buy {
+		return ob.bids
+	}
+
+	return ob.asks
+}
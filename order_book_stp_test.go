@@ -0,0 +1,166 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy/v2"
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfTradePrevention(t *testing.T) {
+	type input struct {
+		mode   orderbook.SelfTradePrevention
+		amount decimal.Decimal
+	}
+
+	type snapshot struct {
+		Book      *orderbook.OrderBook
+		Trades    []*orderbook.Trade
+		Cancelled []*orderbook.Order
+		Err       string
+	}
+
+	tests := []struct {
+		name  string
+		input input
+	}{
+		{
+			name:  "skip",
+			input: input{mode: orderbook.STPSkip, amount: decimal.NewFromInt(4)},
+		},
+		{
+			name:  "none",
+			input: input{mode: orderbook.STPNone, amount: decimal.NewFromInt(4)},
+		},
+		{
+			name:  "cancel newest",
+			input: input{mode: orderbook.STPCancelNewest, amount: decimal.NewFromInt(4)},
+		},
+		{
+			name:  "cancel oldest",
+			input: input{mode: orderbook.STPCancelOldest, amount: decimal.NewFromInt(4)},
+		},
+		{
+			name:  "cancel both",
+			input: input{mode: orderbook.STPCancelBoth, amount: decimal.NewFromInt(4)},
+		},
+		{
+			name:  "decrement and cancel maker",
+			input: input{mode: orderbook.STPDecrementAndCancel, amount: decimal.NewFromInt(4)},
+		},
+		{
+			name:  "decrement and cancel taker",
+			input: input{mode: orderbook.STPDecrementAndCancel, amount: decimal.RequireFromString("0.5")},
+		},
+		{
+			name:  "invalid mode",
+			input: input{mode: orderbook.SelfTradePrevention(42), amount: decimal.NewFromInt(4)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given := []byte(`
+				{
+					"bids": [],
+					"asks": [
+						{
+							"id": "1",
+							"traderId": "1",
+							"side": "sell",
+							"amount": "1",
+							"price": "300"
+						},
+						{
+							"id": "2",
+							"traderId": "2",
+							"side": "sell",
+							"amount": "2",
+							"price": "300"
+						},
+						{
+							"id": "3",
+							"traderId": "3",
+							"side": "sell",
+							"amount": "5",
+							"price": "400"
+						}
+					]
+				}
+			`)
+
+			cancelled := make([]*orderbook.Order, 0)
+			book := orderbook.NewOrderBook("", orderbook.WithClock(clock), orderbook.WithCancelHandler(func(order *orderbook.Order) {
+				cancelled = append(cancelled, order)
+			}))
+
+			err := json.Unmarshal(given, book)
+			assert.Nil(t, err)
+
+			trades, err := book.ProcessLimitOrder("4", "1", orderbook.Buy, tt.input.amount, decimal.NewFromInt(400), orderbook.WithSelfTradePrevention(tt.input.mode))
+
+			var errorStr string
+			if err != nil {
+				errorStr = err.Error()
+			}
+
+			s, err := json.Marshal(&snapshot{
+				Book:      book,
+				Trades:    trades,
+				Cancelled: cancelled,
+				Err:       errorStr,
+			})
+
+			assert.Nil(t, err)
+			cupaloy.SnapshotT(t, s)
+		})
+	}
+}
+
+func TestSelfTradePreventionBookDefault(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithDefaultSelfTradePrevention(orderbook.STPCancelNewest))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	quote, err := book.Quote("1", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+	assert.True(t, quote.RemainingAmount().Equal(decimal.NewFromInt(1)))
+
+	execution, err := book.ProcessMarketOrder("2", "1", orderbook.Buy, decimal.NewFromInt(1))
+	assert.Nil(t, err)
+	assert.Empty(t, execution.Trades())
+
+	execution, err = book.ProcessMarketOrder("3", "1", orderbook.Buy, decimal.NewFromInt(1), orderbook.WithSelfTradePrevention(orderbook.STPNone))
+	assert.Nil(t, err)
+	assert.Len(t, execution.Trades(), 1)
+}
+
+func TestSelfTradePreventionDecrementIceberg(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithDefaultSelfTradePrevention(orderbook.STPDecrementAndCancel))
+
+	_, err := book.ProcessLimitOrder("1", "1", orderbook.Sell, decimal.NewFromInt(10), decimal.NewFromInt(100), orderbook.WithDisplayAmount(decimal.NewFromInt(2)))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("2", "1", orderbook.Buy, decimal.NewFromInt(5), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+
+	orders := book.OpenOrders("1")
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "2", orders[0].Amount().String())
+	assert.Equal(t, "3", orders[0].HiddenAmount().String())
+
+	trades, err = book.ProcessLimitOrder("3", "1", orderbook.Buy, decimal.NewFromInt(7), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Empty(t, trades)
+	assert.Empty(t, book.Depth().Asks())
+
+	orders = book.OpenOrders("1")
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "3", orders[0].ID())
+	assert.Equal(t, "2", orders[0].Amount().String())
+}
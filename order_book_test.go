@@ -0,0 +1,8 @@
+package orderbook_test
+
+import "time"
+
+// clock is the book clock of the snapshot tests.
+func clock() time.Time {
+	return time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+}
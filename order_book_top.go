@@ -0,0 +1,127 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+var bps = decimal.NewFromInt(10000)
+
+// BestBid returns the highest bid price and its amount, zero when there are no bids.
+func (ob *OrderBook) BestBid() (price, amount decimal.Decimal) {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return best(ob.bids.MaxPriceQueue())
+}
+
+// BestAsk returns the lowest ask price and its amount, zero when there are no asks.
+func (ob *OrderBook) BestAsk() (price, amount decimal.Decimal) {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	return best(ob.asks.MinPriceQueue())
+}
+
+// Spread returns the best ask minus the best bid, zero when a side is empty.
+func (ob *OrderBook) Spread() decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	bid, _, ask, _, ok := ob.top()
+	if !ok {
+		return decimal.Zero
+	}
+
+	return ask.Sub(bid)
+}
+
+// SpreadBps returns the spread in basis points of the mid price, zero when a side is empty.
+func (ob *OrderBook) SpreadBps() decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	bid, _, ask, _, ok := ob.top()
+	if !ok {
+		return decimal.Zero
+	}
+
+	return ask.Sub(bid).Mul(bps).Div(mid(bid, ask))
+}
+
+// MidPrice returns the average of the best bid and ask, zero when a side is empty.
+func (ob *OrderBook) MidPrice() decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	bid, _, ask, _, ok := ob.top()
+	if !ok {
+		return decimal.Zero
+	}
+
+	return mid(bid, ask)
+}
+
+// MicroPrice returns the best bid and ask weighted by the amount on the other side, zero when a side is empty.
+// It leans toward the ask when the bids are larger, as the price is more likely to go up.
+func (ob *OrderBook) MicroPrice() decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	bid, bidAmount, ask, askAmount, ok := ob.top()
+	if !ok {
+		return decimal.Zero
+	}
+
+	return bid.Mul(askAmount).Add(ask.Mul(bidAmount)).Div(bidAmount.Add(askAmount))
+}
+
+// Imbalance returns the bid amount minus the ask amount over their sum, for the best levels of each side, all of them
+// when levels is zero. It goes from -1, only asks, to 1, only bids, and is zero when the book is empty.
+func (ob *OrderBook) Imbalance(levels int) decimal.Decimal {
+	defer ob.RUnlock()
+	ob.RLock()
+
+	amount := func(side *OrderSide) decimal.Decimal {
+		amount, n := decimal.Zero, 0
+
+		side.each(func(q *OrderQueue) bool {
+			amount = amount.Add(q.amount)
+			n++
+
+			return levels <= 0 || n < levels
+		})
+
+		return amount
+	}
+
+	bids, asks := amount(ob.bids), amount(ob.asks)
+	total := bids.Add(asks)
+
+	if total.IsZero() {
+		return decimal.Zero
+	}
+
+	return bids.Sub(asks).Div(total)
+}
+
+// top returns the best bid and ask with their amounts, and false when a side is empty.
+func (ob *OrderBook) top() (bid, bidAmount, ask, askAmount decimal.Decimal, ok bool) {
+	bids, asks := ob.bids.MaxPriceQueue(), ob.asks.MinPriceQueue()
+	if bids == nil || asks == nil {
+		return
+	}
+
+	return bids.price, bids.amount, asks.price, asks.amount, true
+}
+
+func best(q *OrderQueue) (decimal.Decimal, decimal.Decimal) {
+	if q == nil {
+		return decimal.Zero, decimal.Zero
+	}
+
+	return q.price, q.amount
+}
+
+func mid(bid, ask decimal.Decimal) decimal.Decimal {
+	return bid.Add(ask).Div(decimal.NewFromInt(2))
+}
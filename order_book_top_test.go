@@ -0,0 +1,57 @@
+package orderbook_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopOfBook(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD")
+
+	price, amount := book.BestBid()
+	assert.True(t, price.IsZero())
+	assert.True(t, amount.IsZero())
+	assert.True(t, book.Spread().IsZero())
+	assert.True(t, book.SpreadBps().IsZero())
+	assert.True(t, book.MidPrice().IsZero())
+	assert.True(t, book.MicroPrice().IsZero())
+	assert.True(t, book.Imbalance(0).IsZero())
+
+	orders := []struct {
+		side   orderbook.Side
+		amount int64
+		price  int64
+	}{
+		{orderbook.Buy, 3, 99},
+		{orderbook.Buy, 1, 99},
+		{orderbook.Buy, 6, 98},
+		{orderbook.Sell, 1, 101},
+		{orderbook.Sell, 9, 102},
+	}
+
+	for i, o := range orders {
+		id := strconv.Itoa(i)
+		_, err := book.ProcessPostOnlyOrder(id, id, o.side, decimal.NewFromInt(o.amount), decimal.NewFromInt(o.price))
+		assert.Nil(t, err)
+	}
+
+	price, amount = book.BestBid()
+	assert.Equal(t, "99", price.String())
+	assert.Equal(t, "4", amount.String())
+
+	price, amount = book.BestAsk()
+	assert.Equal(t, "101", price.String())
+	assert.Equal(t, "1", amount.String())
+
+	assert.Equal(t, "2", book.Spread().String())
+	assert.Equal(t, "200", book.SpreadBps().String())
+	assert.Equal(t, "100", book.MidPrice().String())
+	assert.Equal(t, "100.6", book.MicroPrice().String())
+	assert.Equal(t, "0.6", book.Imbalance(1).String())
+	assert.True(t, book.Imbalance(2).IsZero())
+	assert.True(t, book.Imbalance(0).IsZero())
+}
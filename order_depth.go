@@ -0,0 +1,156 @@
+package orderbook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*OrderEntry)(nil)
+var _ json.Unmarshaler = (*OrderEntry)(nil)
+var _ json.Marshaler = (*OrderDepth)(nil)
+var _ json.Unmarshaler = (*OrderDepth)(nil)
+
+// OrderEntry represents a resting order as shown by OrderDepth.
+type OrderEntry struct {
+	orderID   string
+	traderTag string
+	price     decimal.Decimal
+	amount    decimal.Decimal
+}
+
+// NewOrderEntry creates a new order entry.
+func NewOrderEntry(orderID, traderTag string, price, amount decimal.Decimal) *OrderEntry {
+	return &OrderEntry{orderID, traderTag, price, amount}
+}
+
+// OrderID returns the order ID.
+func (e *OrderEntry) OrderID() string {
+	return e.orderID
+}
+
+// TraderTag returns the anonymized trader tag, empty when the book does not tag orders.
+func (e *OrderEntry) TraderTag() string {
+	return e.traderTag
+}
+
+// Price returns the price.
+func (e *OrderEntry) Price() decimal.Decimal {
+	return e.price
+}
+
+// Amount returns the displayed amount.
+func (e *OrderEntry) Amount() decimal.Decimal {
+	return e.amount
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *OrderEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			OrderID   string          `json:"orderId"`
+			TraderTag string          `json:"traderTag,omitempty"`
+			Price     decimal.Decimal `json:"price"`
+			Amount    decimal.Decimal `json:"amount"`
+		}{
+			e.orderID,
+			e.traderTag,
+			e.price,
+			e.amount,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *OrderEntry) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		OrderID   string          `json:"orderId"`
+		TraderTag string          `json:"traderTag"`
+		Price     decimal.Decimal `json:"price"`
+		Amount    decimal.Decimal `json:"amount"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("OrderEntry.Unmarshal(%s): %w", data, err)
+	}
+
+	e.orderID = obj.OrderID
+	e.traderTag = obj.TraderTag
+	e.price = obj.Price
+	e.amount = obj.Amount
+
+	return nil
+}
+
+// OrderDepth represents every resting order of an order book, best prices first and in time priority within a price.
+type OrderDepth struct {
+	bids    []*OrderEntry
+	asks    []*OrderEntry
+	version uint64
+}
+
+// NewOrderDepth creates a new order depth.
+func NewOrderDepth(bids, asks []*OrderEntry, version uint64) *OrderDepth {
+	return &OrderDepth{bids, asks, version}
+}
+
+// Bids returns the bids.
+func (d *OrderDepth) Bids() []*OrderEntry {
+	return d.bids
+}
+
+// Asks returns the asks.
+func (d *OrderDepth) Asks() []*OrderEntry {
+	return d.asks
+}
+
+// Version returns the book version the order depth was taken at.
+func (d *OrderDepth) Version() uint64 {
+	return d.version
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *OrderDepth) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Bids    []*OrderEntry `json:"bids"`
+			Asks    []*OrderEntry `json:"asks"`
+			Version uint64        `json:"version"`
+		}{
+			d.bids,
+			d.asks,
+			d.version,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *OrderDepth) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Bids    []*OrderEntry `json:"bids"`
+		Asks    []*OrderEntry `json:"asks"`
+		Version uint64        `json:"version"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("OrderDepth.Unmarshal(%s): %w", data, err)
+	}
+
+	d.bids = obj.Bids
+	d.asks = obj.Asks
+	d.version = obj.Version
+
+	return nil
+}
+
+// HashTraderTag returns a trader tag function for WithTraderTags hashing the salted trader ID.
+// The same trader always gets the same tag, which can not be turned back into the trader ID without the salt.
+func HashTraderTag(salt string) func(traderID string) string {
+	return func(traderID string) string {
+		sum := sha256.Sum256([]byte(salt + traderID))
+		return hex.EncodeToString(sum[:8])
+	}
+}
@@ -68,3 +68,10 @@ func (oq *OrderQueue) Remove(e *list.Element) *Order {
 	oq.amount = oq.amount.Sub(e.Value.(*Order).amount)
 	return oq.orders.Remove(e).(*Order)
 }
+
+// Replenish refills an iceberg order from its hidden reserve and moves it to the back of the queue.
+func (oq *OrderQueue) Replenish(e *list.Element) *list.Element {
+	order := oq.Remove(e)
+	order.replenish()
+	return oq.Append(order)
+}
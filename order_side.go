@@ -2,49 +2,58 @@ package orderbook
 
 import (
 	"container/list"
-	"sort"
 
-	"github.com/emirpasic/gods/examples/redblacktreeextended"
-	"github.com/emirpasic/gods/trees/redblacktree"
 	"github.com/shopspring/decimal"
 )
 
 // OrderSide represents all the prices about bids or asks.
 type OrderSide struct {
 	side   Side
-	tree   *redblacktreeextended.RedBlackTreeExtended
-	queue  map[decimal.Decimal]*OrderQueue
+	levels priceLevels
 	amount decimal.Decimal
 	size   int
 	depth  int
+
+	onChange func(side Side, price, amount decimal.Decimal)
+	onOrder  func(updateType OrderUpdateType, order *Order, previous decimal.Decimal)
 }
 
 // NewOrderSide creates a new order side.
 func NewOrderSide(side Side) *OrderSide {
-	tree := &redblacktreeextended.RedBlackTreeExtended{
-		Tree: redblacktree.NewWith(func(a, b interface{}) int {
-			return a.(decimal.Decimal).Cmp(b.(decimal.Decimal))
-		}),
+	return &OrderSide{side, newTreeLevels(), decimal.Zero, 0, 0, nil, nil}
+}
+
+// NewDenseOrderSide creates a new order side keeping the prices from min to max price in an array indexed by tick offset,
+// with O(1) access to the best price. The next level is found by scanning the empty ticks in between, so a sparse band
+// is slower than a tree. Prices off the tick grid or out of the band are kept in a tree.
+// It fails with ErrTooManyPriceLevels when the band holds more than MaxDenseLevels ticks.
+func NewDenseOrderSide(side Side, tickSize, minPrice, maxPrice decimal.Decimal) (*OrderSide, error) {
+	levels, err := newDenseLevels(tickSize, minPrice, maxPrice)
+	if err != nil {
+		return nil, err
 	}
 
-	return &OrderSide{side, tree, make(map[decimal.Decimal]*OrderQueue), decimal.Zero, 0, 0}
+	return &OrderSide{side, levels, decimal.Zero, 0, 0, nil, nil}, nil
 }
 
 // Append appends an order.
 func (os *OrderSide) Append(order *Order) *list.Element {
 	price := order.price
 
-	priceQueue, ok := os.queue[price]
-	if !ok {
+	priceQueue := os.levels.get(price)
+	if priceQueue == nil {
 		priceQueue = NewOrderQueue(price)
-		os.queue[order.price] = priceQueue
-		os.tree.Put(price, priceQueue)
+		os.levels.put(priceQueue)
 		os.depth++
 	}
 
 	os.size++
 	os.amount = os.amount.Add(order.amount)
-	return priceQueue.Append(order)
+	e := priceQueue.Append(order)
+	os.changed(priceQueue)
+	os.ordered(OrderAdded, order, decimal.Zero)
+
+	return e
 }
 
 // Remove removes an order.
@@ -52,17 +61,19 @@ func (os *OrderSide) Remove(e *list.Element) *Order {
 	order := e.Value.(*Order)
 	price := order.price
 
-	priceQueue := os.queue[price]
+	priceQueue := os.levels.get(price)
 	o := priceQueue.Remove(e)
 
 	if priceQueue.Len() == 0 {
-		delete(os.queue, price)
-		os.tree.Remove(price)
+		os.levels.remove(price)
 		os.depth--
 	}
 
 	os.size--
 	os.amount = os.amount.Sub(o.Amount())
+	os.changed(priceQueue)
+	os.ordered(OrderDeleted, o, o.amount)
+
 	return o
 }
 
@@ -70,27 +81,62 @@ func (os *OrderSide) Remove(e *list.Element) *Order {
 func (os *OrderSide) UpdateAmount(e *list.Element, amount decimal.Decimal) *Order {
 	order := e.Value.(*Order)
 	price := order.price
+	previous := order.amount
 
 	os.amount = os.amount.Sub(order.amount)
 	os.amount = os.amount.Add(amount)
 
-	priceQueue := os.queue[price]
+	priceQueue := os.levels.get(price)
 	o := priceQueue.UpdateAmount(e, amount)
+	os.changed(priceQueue)
+	os.ordered(OrderModified, o, previous)
 
 	return o
 }
 
+// Replenish refills an iceberg order from its hidden reserve and moves it to the back of its price queue.
+func (os *OrderSide) Replenish(e *list.Element) *list.Element {
+	order := e.Value.(*Order)
+
+	priceQueue := os.levels.get(order.price)
+
+	os.amount = os.amount.Sub(order.amount)
+	os.ordered(OrderDeleted, order, order.amount)
+	e = priceQueue.Replenish(e)
+	os.amount = os.amount.Add(order.amount)
+	os.changed(priceQueue)
+	os.ordered(OrderAdded, order, decimal.Zero)
+
+	return e
+}
+
+// changed reports the new amount of a price queue, zero when it is empty.
+func (os *OrderSide) changed(priceQueue *OrderQueue) {
+	if os.onChange == nil {
+		return
+	}
+
+	if priceQueue.Len() == 0 {
+		os.onChange(os.side, priceQueue.price, decimal.Zero)
+	} else {
+		os.onChange(os.side, priceQueue.price, priceQueue.amount)
+	}
+}
+
+// ordered reports a change of a resting order, with its amount before the change.
+func (os *OrderSide) ordered(updateType OrderUpdateType, order *Order, previous decimal.Decimal) {
+	if os.onOrder != nil {
+		os.onOrder(updateType, order, previous)
+	}
+}
+
 // MaxPriceQueue returns the order queue for the max price.
 func (os *OrderSide) MaxPriceQueue() *OrderQueue {
 	if os.depth <= 0 {
 		return nil
 	}
 
-	if value, found := os.tree.GetMax(); found {
-		return value.(*OrderQueue)
-	}
-
-	return nil
+	return os.levels.max()
 }
 
 // MinPriceQueue returns the order queue for the min price.
@@ -99,79 +145,50 @@ func (os *OrderSide) MinPriceQueue() *OrderQueue {
 		return nil
 	}
 
-	if value, found := os.tree.GetMin(); found {
-		return value.(*OrderQueue)
-	}
-
-	return nil
+	return os.levels.min()
 }
 
 // LessThan returns the order queue for the price less than the given price.
 func (os *OrderSide) LessThan(price decimal.Decimal) *OrderQueue {
-	tree := os.tree.Tree
-	node := tree.Root
-
-	var floor *redblacktree.Node
-	for node != nil {
-		if tree.Comparator(price, node.Key) > 0 {
-			floor = node
-			node = node.Right
-		} else {
-			node = node.Left
-		}
-	}
-
-	if floor != nil {
-		return floor.Value.(*OrderQueue)
-	}
-
-	return nil
+	return os.levels.lessThan(price)
 }
 
 // GreaterThan returns the order queue for the price greater than the given price.
 func (os *OrderSide) GreaterThan(price decimal.Decimal) *OrderQueue {
-	tree := os.tree.Tree
-	node := tree.Root
-
-	var ceiling *redblacktree.Node
-	for node != nil {
-		if tree.Comparator(price, node.Key) < 0 {
-			ceiling = node
-			node = node.Left
-		} else {
-			node = node.Right
-		}
-	}
-
-	if ceiling != nil {
-		return ceiling.Value.(*OrderQueue)
-	}
-
-	return nil
+	return os.levels.greaterThan(price)
 }
 
-// Orders return all the orders sorted by price. Desc when side is buy. Asc when side is sell.
+// Orders return all the orders sorted by price, in time priority within a price. Desc when side is buy. Asc when side is sell.
 func (os *OrderSide) Orders() []*Order {
-	orders := make([]*Order, 0)
+	orders := make([]*Order, 0, os.size)
 
-	for _, price := range os.queue {
+	os.each(func(price *OrderQueue) bool {
 		iter := price.Front()
 
 		for iter != nil {
 			orders = append(orders, iter.Value.(*Order))
 			iter = iter.Next()
 		}
-	}
 
+		return true
+	})
+
+	return orders
+}
+
+// each calls fn with every price queue, best price first, until it returns false.
+func (os *OrderSide) each(fn func(price *OrderQueue) bool) {
 	if os.side == Buy {
-		sort.Slice(orders[:], func(i, j int) bool {
-			return orders[i].price.GreaterThan(orders[j].price)
-		})
+		for q := os.MaxPriceQueue(); q != nil; q = os.LessThan(q.price) {
+			if !fn(q) {
+				return
+			}
+		}
 	} else {
-		sort.Slice(orders[:], func(i, j int) bool {
-			return orders[i].price.LessThan(orders[j].price)
-		})
+		for q := os.MinPriceQueue(); q != nil; q = os.GreaterThan(q.price) {
+			if !fn(q) {
+				return
+			}
+		}
 	}
-
-	return orders
 }
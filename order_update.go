@@ -0,0 +1,192 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*OrderUpdateType)(nil)
+var _ json.Unmarshaler = (*OrderUpdateType)(nil)
+var _ json.Marshaler = (*OrderUpdate)(nil)
+var _ json.Unmarshaler = (*OrderUpdate)(nil)
+
+// An OrderUpdateType tells how a resting order changed.
+type OrderUpdateType int
+
+const (
+	// OrderAdded when an order is added to the back of its price level
+	OrderAdded OrderUpdateType = 0
+
+	// OrderModified when the amount of an order is changed in place, keeping its priority
+	OrderModified OrderUpdateType = 1
+
+	// OrderDeleted when an order is removed without trading
+	OrderDeleted OrderUpdateType = 2
+
+	// OrderExecuted when an order is traded, removed when nothing is left
+	OrderExecuted OrderUpdateType = 3
+)
+
+var orderUpdateTypes = []string{"add", "modify", "delete", "execute"}
+
+// String implements fmt.Stringer.
+func (t OrderUpdateType) String() string {
+	if t < 0 || int(t) >= len(orderUpdateTypes) {
+		return "unknown"
+	}
+
+	return orderUpdateTypes[t]
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t OrderUpdateType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *OrderUpdateType) UnmarshalJSON(data []byte) error {
+	for i, name := range orderUpdateTypes {
+		if string(data) == `"`+name+`"` {
+			*t = OrderUpdateType(i)
+			return nil
+		}
+	}
+
+	return &json.UnsupportedValueError{
+		Value: reflect.New(reflect.TypeOf(data)),
+		Str:   string(data),
+	}
+}
+
+// OrderUpdate represents the change of a resting order, keyed by order ID.
+// The amount is what is left resting after the change, zero when the order was removed.
+type OrderUpdate struct {
+	updateType OrderUpdateType
+	orderID    string
+	traderTag  string
+	side       Side
+	price      decimal.Decimal
+	amount     decimal.Decimal
+	executed   decimal.Decimal
+	version    uint64
+}
+
+// NewOrderUpdate creates a new order update.
+func NewOrderUpdate(updateType OrderUpdateType, orderID, traderTag string, side Side, price, amount, executed decimal.Decimal, version uint64) *OrderUpdate {
+	return &OrderUpdate{updateType, orderID, traderTag, side, price, amount, executed, version}
+}
+
+// Type returns the update type.
+func (u *OrderUpdate) Type() OrderUpdateType {
+	return u.updateType
+}
+
+// OrderID returns the order ID.
+func (u *OrderUpdate) OrderID() string {
+	return u.orderID
+}
+
+// TraderTag returns the anonymized trader tag, empty when the book does not tag orders.
+func (u *OrderUpdate) TraderTag() string {
+	return u.traderTag
+}
+
+// Side returns the side.
+func (u *OrderUpdate) Side() Side {
+	return u.side
+}
+
+// Price returns the price.
+func (u *OrderUpdate) Price() decimal.Decimal {
+	return u.price
+}
+
+// Amount returns the amount left resting.
+func (u *OrderUpdate) Amount() decimal.Decimal {
+	return u.amount
+}
+
+// Executed returns the traded amount of OrderExecuted.
+func (u *OrderUpdate) Executed() decimal.Decimal {
+	return u.executed
+}
+
+// Version returns the book version produced by the change.
+func (u *OrderUpdate) Version() uint64 {
+	return u.version
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u *OrderUpdate) MarshalJSON() ([]byte, error) {
+	var executed *decimal.Decimal
+	if !u.executed.IsZero() {
+		executed = &u.executed
+	}
+
+	return json.Marshal(
+		&struct {
+			Type      OrderUpdateType  `json:"type"`
+			OrderID   string           `json:"orderId"`
+			TraderTag string           `json:"traderTag,omitempty"`
+			Side      Side             `json:"side"`
+			Price     decimal.Decimal  `json:"price"`
+			Amount    decimal.Decimal  `json:"amount"`
+			Executed  *decimal.Decimal `json:"executed,omitempty"`
+			Version   uint64           `json:"version"`
+		}{
+			u.updateType,
+			u.orderID,
+			u.traderTag,
+			u.side,
+			u.price,
+			u.amount,
+			executed,
+			u.version,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *OrderUpdate) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Type      OrderUpdateType `json:"type"`
+		OrderID   string          `json:"orderId"`
+		TraderTag string          `json:"traderTag"`
+		Side      Side            `json:"side"`
+		Price     decimal.Decimal `json:"price"`
+		Amount    decimal.Decimal `json:"amount"`
+		Executed  decimal.Decimal `json:"executed"`
+		Version   uint64          `json:"version"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("OrderUpdate.Unmarshal(%s): %w", data, err)
+	}
+
+	u.updateType = obj.Type
+	u.orderID = obj.OrderID
+	u.traderTag = obj.TraderTag
+	u.side = obj.Side
+	u.price = obj.Price
+	u.amount = obj.Amount
+	u.executed = obj.Executed
+	u.version = obj.Version
+
+	return nil
+}
+
+// OrderListener receives the order updates of an order book.
+type OrderListener interface {
+	OnOrderUpdate(update *OrderUpdate)
+}
+
+// OrderListenerFunc calls the function with each order update.
+type OrderListenerFunc func(update *OrderUpdate)
+
+// OnOrderUpdate implements OrderListener.
+func (f OrderListenerFunc) OnOrderUpdate(update *OrderUpdate) {
+	f(update)
+}
@@ -0,0 +1,112 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*PriceBand)(nil)
+var _ json.Unmarshaler = (*PriceBand)(nil)
+
+// PriceBand represents the circuit breaker of a market. Bands are fractions of their reference, 0.05 means 5%. A zero band is not checked.
+type PriceBand struct {
+	referencePrice decimal.Decimal
+	static         decimal.Decimal
+	dynamic        decimal.Decimal
+	breachStatus   TradingStatus
+	cooldown       time.Duration
+}
+
+// NewPriceBand creates a new price band.
+// The static band is centered on the reference price and the dynamic band on the last price before each order.
+// A breach moves an open market to the breach status, Open meaning it only stops the matching, for the cooldown.
+func NewPriceBand(referencePrice, static, dynamic decimal.Decimal, breachStatus TradingStatus, cooldown time.Duration) *PriceBand {
+	return &PriceBand{referencePrice, static, dynamic, breachStatus, cooldown}
+}
+
+// ReferencePrice returns the center of the static band.
+func (b *PriceBand) ReferencePrice() decimal.Decimal {
+	return b.referencePrice
+}
+
+// Static returns the static band.
+func (b *PriceBand) Static() decimal.Decimal {
+	return b.static
+}
+
+// Dynamic returns the dynamic band.
+func (b *PriceBand) Dynamic() decimal.Decimal {
+	return b.dynamic
+}
+
+// BreachStatus returns the trading status the market moves to on a breach.
+func (b *PriceBand) BreachStatus() TradingStatus {
+	return b.breachStatus
+}
+
+// Cooldown returns how long the market stays in the breach status. Zero means until the status is changed.
+func (b *PriceBand) Cooldown() time.Duration {
+	return b.cooldown
+}
+
+// Allows tells if a trade can happen at the price given the last price.
+func (b *PriceBand) Allows(price, lastPrice decimal.Decimal) bool {
+	if b == nil {
+		return true
+	}
+
+	return within(price, b.referencePrice, b.static) && within(price, lastPrice, b.dynamic)
+}
+
+func within(price, reference, band decimal.Decimal) bool {
+	if reference.IsZero() || band.IsZero() {
+		return true
+	}
+
+	return price.Sub(reference).Abs().LessThanOrEqual(reference.Mul(band))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *PriceBand) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			ReferencePrice decimal.Decimal `json:"referencePrice"`
+			Static         decimal.Decimal `json:"static"`
+			Dynamic        decimal.Decimal `json:"dynamic"`
+			BreachStatus   TradingStatus   `json:"breachStatus"`
+			Cooldown       time.Duration   `json:"cooldown"`
+		}{
+			b.referencePrice,
+			b.static,
+			b.dynamic,
+			b.breachStatus,
+			b.cooldown,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *PriceBand) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		ReferencePrice decimal.Decimal `json:"referencePrice"`
+		Static         decimal.Decimal `json:"static"`
+		Dynamic        decimal.Decimal `json:"dynamic"`
+		BreachStatus   TradingStatus   `json:"breachStatus"`
+		Cooldown       time.Duration   `json:"cooldown"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("PriceBand.Unmarshal(%s): %w", data, err)
+	}
+
+	b.referencePrice = obj.ReferencePrice
+	b.static = obj.Static
+	b.dynamic = obj.Dynamic
+	b.breachStatus = obj.BreachStatus
+	b.cooldown = obj.Cooldown
+
+	return nil
+}
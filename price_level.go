@@ -11,14 +11,18 @@ var _ json.Marshaler = (*PriceLevel)(nil)
 var _ json.Unmarshaler = (*PriceLevel)(nil)
 
 // PriceLevel takes a count of how many assets have that price.
+// The order count and cumulative totals are only set when asked for with the depth options.
 type PriceLevel struct {
-	price  decimal.Decimal
-	amount decimal.Decimal
+	price              decimal.Decimal
+	amount             decimal.Decimal
+	orders             int
+	cumulativeAmount   decimal.Decimal
+	cumulativeNotional decimal.Decimal
 }
 
 // NewPriceLevel creates a new price level.
 func NewPriceLevel(price, amount decimal.Decimal) *PriceLevel {
-	return &PriceLevel{price, amount}
+	return &PriceLevel{price, amount, 0, decimal.Zero, decimal.Zero}
 }
 
 // Price returns the price.
@@ -31,15 +35,42 @@ func (p *PriceLevel) Amount() decimal.Decimal {
 	return p.amount
 }
 
+// Orders returns the count of orders, see WithOrderCounts.
+func (p *PriceLevel) Orders() int {
+	return p.orders
+}
+
+// CumulativeAmount returns the amount of this level and the better ones, see WithCumulative.
+func (p *PriceLevel) CumulativeAmount() decimal.Decimal {
+	return p.cumulativeAmount
+}
+
+// CumulativeNotional returns the amount times price of this level and the better ones, see WithCumulative.
+func (p *PriceLevel) CumulativeNotional() decimal.Decimal {
+	return p.cumulativeNotional
+}
+
 // MarshalJSON implements json.Marshaler.
 func (p *PriceLevel) MarshalJSON() ([]byte, error) {
+	var cumulativeAmount, cumulativeNotional *decimal.Decimal
+	if !p.cumulativeAmount.IsZero() {
+		cumulativeAmount = &p.cumulativeAmount
+		cumulativeNotional = &p.cumulativeNotional
+	}
+
 	return json.Marshal(
 		&struct {
-			Amount decimal.Decimal `json:"amount"`
-			Price  decimal.Decimal `json:"price"`
+			Amount             decimal.Decimal  `json:"amount"`
+			Price              decimal.Decimal  `json:"price"`
+			Orders             int              `json:"orders,omitempty"`
+			CumulativeAmount   *decimal.Decimal `json:"cumulativeAmount,omitempty"`
+			CumulativeNotional *decimal.Decimal `json:"cumulativeNotional,omitempty"`
 		}{
 			p.amount,
 			p.price,
+			p.orders,
+			cumulativeAmount,
+			cumulativeNotional,
 		},
 	)
 }
@@ -47,8 +78,11 @@ func (p *PriceLevel) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements json.Unmarshaler.
 func (p *PriceLevel) UnmarshalJSON(data []byte) error {
 	obj := struct {
-		Amount decimal.Decimal `json:"amount"`
-		Price  decimal.Decimal `json:"price"`
+		Amount             decimal.Decimal `json:"amount"`
+		Price              decimal.Decimal `json:"price"`
+		Orders             int             `json:"orders"`
+		CumulativeAmount   decimal.Decimal `json:"cumulativeAmount"`
+		CumulativeNotional decimal.Decimal `json:"cumulativeNotional"`
 	}{}
 
 	if err := json.Unmarshal(data, &obj); err != nil {
@@ -57,6 +91,9 @@ func (p *PriceLevel) UnmarshalJSON(data []byte) error {
 
 	p.amount = obj.Amount
 	p.price = obj.Price
+	p.orders = obj.Orders
+	p.cumulativeAmount = obj.CumulativeAmount
+	p.cumulativeNotional = obj.CumulativeNotional
 
 	return nil
 }
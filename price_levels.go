@@ -0,0 +1,287 @@
+package orderbook
+
+import (
+	"github.com/emirpasic/gods/examples/redblacktreeextended"
+	"github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+)
+
+// priceLevels indexes the order queues of a side by price.
+type priceLevels interface {
+	get(price decimal.Decimal) *OrderQueue
+	put(queue *OrderQueue)
+	remove(price decimal.Decimal)
+	max() *OrderQueue
+	min() *OrderQueue
+	lessThan(price decimal.Decimal) *OrderQueue
+	greaterThan(price decimal.Decimal) *OrderQueue
+}
+
+// treeLevels keeps the order queues in a red-black tree, for any price.
+// Queues are looked up by the price string, as equal decimals, such as 100 and 100.0, are distinct map keys.
+type treeLevels struct {
+	tree  *redblacktreeextended.RedBlackTreeExtended
+	queue map[string]*OrderQueue
+}
+
+func newTreeLevels() *treeLevels {
+	tree := &redblacktreeextended.RedBlackTreeExtended{
+		Tree: redblacktree.NewWith(func(a, b interface{}) int {
+			return a.(decimal.Decimal).Cmp(b.(decimal.Decimal))
+		}),
+	}
+
+	return &treeLevels{tree, make(map[string]*OrderQueue)}
+}
+
+func (l *treeLevels) get(price decimal.Decimal) *OrderQueue {
+	return l.queue[price.String()]
+}
+
+func (l *treeLevels) put(queue *OrderQueue) {
+	l.queue[queue.price.String()] = queue
+	l.tree.Put(queue.price, queue)
+}
+
+func (l *treeLevels) remove(price decimal.Decimal) {
+	delete(l.queue, price.String())
+	l.tree.Remove(price)
+}
+
+func (l *treeLevels) max() *OrderQueue {
+	if value, found := l.tree.GetMax(); found {
+		return value.(*OrderQueue)
+	}
+
+	return nil
+}
+
+func (l *treeLevels) min() *OrderQueue {
+	if value, found := l.tree.GetMin(); found {
+		return value.(*OrderQueue)
+	}
+
+	return nil
+}
+
+func (l *treeLevels) lessThan(price decimal.Decimal) *OrderQueue {
+	tree := l.tree.Tree
+	node := tree.Root
+
+	var floor *redblacktree.Node
+	for node != nil {
+		if tree.Comparator(price, node.Key) > 0 {
+			floor = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+
+	if floor != nil {
+		return floor.Value.(*OrderQueue)
+	}
+
+	return nil
+}
+
+func (l *treeLevels) greaterThan(price decimal.Decimal) *OrderQueue {
+	tree := l.tree.Tree
+	node := tree.Root
+
+	var ceiling *redblacktree.Node
+	for node != nil {
+		if tree.Comparator(price, node.Key) < 0 {
+			ceiling = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+
+	if ceiling != nil {
+		return ceiling.Value.(*OrderQueue)
+	}
+
+	return nil
+}
+
+// MaxDenseLevels is the most price levels, from min to max price, a dense order side keeps in its array.
+const MaxDenseLevels = 1 << 20
+
+// denseLevels keeps the order queues from min to max price in an array indexed by tick offset, with cursors on the
+// lowest and highest occupied levels. Prices off the tick grid or out of the band go to a tree.
+type denseLevels struct {
+	tickSize decimal.Decimal
+	minPrice decimal.Decimal
+	levels   []*OrderQueue
+	low      int
+	high     int
+	sparse   *treeLevels
+}
+
+func newDenseLevels(tickSize, minPrice, maxPrice decimal.Decimal) (*denseLevels, error) {
+	n, err := denseSize(tickSize, minPrice, maxPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &denseLevels{tickSize, minPrice, make([]*OrderQueue, n), -1, -1, newTreeLevels()}, nil
+}
+
+// denseSize returns the number of ticks from min to max price, both included, bounded by MaxDenseLevels.
+func denseSize(tickSize, minPrice, maxPrice decimal.Decimal) (int, error) {
+	if !tickSize.IsPositive() {
+		return 0, ErrInvalidTickSize
+	}
+
+	if minPrice.IsNegative() || maxPrice.LessThan(minPrice) {
+		return 0, ErrInvalidPrice
+	}
+
+	n, _ := maxPrice.Sub(minPrice).QuoRem(tickSize, 0)
+	if n.GreaterThanOrEqual(decimal.NewFromInt(MaxDenseLevels)) {
+		return 0, ErrTooManyPriceLevels
+	}
+
+	return int(n.IntPart()) + 1, nil
+}
+
+// offset returns the tick offset of a price below or at it, and whether the price is exactly on it.
+// Prices out of the band return -1 below it and len(levels) above it.
+func (l *denseLevels) offset(price decimal.Decimal) (int, bool) {
+	if len(l.levels) == 0 || price.LessThan(l.minPrice) {
+		return -1, false
+	}
+
+	q, r := price.Sub(l.minPrice).QuoRem(l.tickSize, 0)
+	if q.GreaterThanOrEqual(decimal.NewFromInt(int64(len(l.levels)))) {
+		return len(l.levels), false
+	}
+
+	return int(q.IntPart()), r.IsZero()
+}
+
+func (l *denseLevels) get(price decimal.Decimal) *OrderQueue {
+	if i, ok := l.offset(price); ok {
+		return l.levels[i]
+	}
+
+	return l.sparse.get(price)
+}
+
+func (l *denseLevels) put(queue *OrderQueue) {
+	i, ok := l.offset(queue.price)
+	if !ok {
+		l.sparse.put(queue)
+		return
+	}
+
+	l.levels[i] = queue
+
+	if l.low < 0 || i < l.low {
+		l.low = i
+	}
+
+	if i > l.high {
+		l.high = i
+	}
+}
+
+func (l *denseLevels) remove(price decimal.Decimal) {
+	i, ok := l.offset(price)
+	if !ok {
+		l.sparse.remove(price)
+		return
+	}
+
+	if l.levels[i] == nil {
+		return
+	}
+
+	l.levels[i] = nil
+
+	if l.low == l.high {
+		l.low, l.high = -1, -1
+		return
+	}
+
+	if i == l.low {
+		l.low = l.next(i+1, 1)
+	}
+
+	if i == l.high {
+		l.high = l.next(i-1, -1)
+	}
+}
+
+// next returns the first occupied level from i in the direction, bounded by the cursors, or -1.
+func (l *denseLevels) next(i, direction int) int {
+	if l.low < 0 {
+		return -1
+	}
+
+	for ; i >= l.low && i <= l.high; i += direction {
+		if l.levels[i] != nil {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (l *denseLevels) at(i int) *OrderQueue {
+	if i < 0 {
+		return nil
+	}
+
+	return l.levels[i]
+}
+
+func (l *denseLevels) max() *OrderQueue {
+	return higher(l.at(l.high), l.sparse.max())
+}
+
+func (l *denseLevels) min() *OrderQueue {
+	return lower(l.at(l.low), l.sparse.min())
+}
+
+func (l *denseLevels) lessThan(price decimal.Decimal) *OrderQueue {
+	i, ok := l.offset(price)
+	if ok {
+		i--
+	}
+
+	if i > l.high {
+		i = l.high
+	}
+
+	return higher(l.at(l.next(i, -1)), l.sparse.lessThan(price))
+}
+
+func (l *denseLevels) greaterThan(price decimal.Decimal) *OrderQueue {
+	i, _ := l.offset(price)
+	i++
+
+	if i < l.low {
+		i = l.low
+	}
+
+	return lower(l.at(l.next(i, 1)), l.sparse.greaterThan(price))
+}
+
+func higher(a, b *OrderQueue) *OrderQueue {
+	if a == nil || (b != nil && b.price.GreaterThan(a.price)) {
+		return b
+	}
+
+	return a
+}
+
+func lower(a, b *OrderQueue) *OrderQueue {
+	if a == nil || (b != nil && b.price.LessThan(a.price)) {
+		return b
+	}
+
+	return a
+}
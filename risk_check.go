@@ -0,0 +1,136 @@
+package orderbook
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// BookView is a read only view of an order book.
+type BookView interface {
+	// Symbol returns the symbol.
+	Symbol() string
+
+	// Status returns the trading status.
+	Status() TradingStatus
+
+	// LastPrice returns the price of the last trade or zero when nothing was traded yet.
+	LastPrice() decimal.Decimal
+
+	// BestBid returns the highest bid price or zero when there are no bids.
+	BestBid() decimal.Decimal
+
+	// BestAsk returns the lowest ask price or zero when there are no asks.
+	BestAsk() decimal.Decimal
+
+	// Depth returns the depth.
+	Depth() *Depth
+
+	// Order returns a copy of a resting or stop order or nil when it is not found.
+	Order(orderID string) *Order
+
+	// OpenOrders returns the number of resting and stop orders of a trader.
+	OpenOrders(traderID string) int
+}
+
+// RiskCheck validates the orders before they reach the book. Returning an error rejects the order with it.
+type RiskCheck interface {
+	Check(order *Order, book BookView) error
+}
+
+// TradeCheck is a risk check also called with each trade, fees included, right before it executes.
+// Returning an error stops the taker from matching, cancels what is left of it and fails its call with the error,
+// except ErrMakerInsufficientFunds, which cancels the maker instead and lets the taker keep matching.
+type TradeCheck interface {
+	RiskCheck
+	CheckTrade(trade *Trade) error
+}
+
+// RiskCheckFunc calls the function to check each order.
+type RiskCheckFunc func(order *Order, book BookView) error
+
+// Check implements RiskCheck.
+func (f RiskCheckFunc) Check(order *Order, book BookView) error {
+	return f(order, book)
+}
+
+// MaxAmount rejects orders larger than the amount.
+func MaxAmount(amount decimal.Decimal) RiskCheck {
+	return RiskCheckFunc(func(order *Order, book BookView) error {
+		if order.amount.GreaterThan(amount) {
+			return ErrMaxAmountExceeded
+		}
+
+		return nil
+	})
+}
+
+// MaxNotional rejects orders whose amount times price is larger than the notional.
+// Market orders are valued at the best opposite price, or by their funds.
+func MaxNotional(notional decimal.Decimal) RiskCheck {
+	return RiskCheckFunc(func(order *Order, book BookView) error {
+		if orderNotional(order, book).GreaterThan(notional) {
+			return ErrMaxNotionalExceeded
+		}
+
+		return nil
+	})
+}
+
+// MaxPriceDistance rejects orders priced further from the best opposite price than the distance, a fraction of that price.
+// The best price of the same side, then the last price, is used when the opposite side is empty. Market orders are not checked.
+func MaxPriceDistance(distance decimal.Decimal) RiskCheck {
+	return RiskCheckFunc(func(order *Order, book BookView) error {
+		if order.price.IsZero() {
+			return nil
+		}
+
+		reference := oppositePrice(order.side, book)
+		if reference.IsZero() {
+			reference = oppositePrice(order.side.Opposite(), book)
+		}
+
+		if reference.IsZero() {
+			reference = book.LastPrice()
+		}
+
+		if reference.IsZero() {
+			return nil
+		}
+
+		if order.price.Sub(reference).Abs().GreaterThan(reference.Mul(distance)) {
+			return ErrPriceTooFar
+		}
+
+		return nil
+	})
+}
+
+// MaxOpenOrders rejects new orders of traders with the count of resting and stop orders already.
+func MaxOpenOrders(count int) RiskCheck {
+	return RiskCheckFunc(func(order *Order, book BookView) error {
+		if book.Order(order.id) == nil && book.OpenOrders(order.traderID) >= count {
+			return ErrTooManyOpenOrders
+		}
+
+		return nil
+	})
+}
+
+func orderNotional(order *Order, book BookView) decimal.Decimal {
+	if order.funds.IsPositive() {
+		return order.funds
+	}
+
+	if order.price.IsPositive() {
+		return order.amount.Mul(order.price)
+	}
+
+	return order.amount.Mul(oppositePrice(order.side, book))
+}
+
+func oppositePrice(side Side, book BookView) decimal.Decimal {
+	if side == Buy {
+		return book.BestAsk()
+	}
+
+	return book.BestBid()
+}
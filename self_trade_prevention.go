@@ -0,0 +1,84 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var _ json.Marshaler = (*SelfTradePrevention)(nil)
+var _ json.Unmarshaler = (*SelfTradePrevention)(nil)
+
+// A SelfTradePrevention tells what happens when a taker meets a resting order of the same trader.
+type SelfTradePrevention int
+
+const (
+	// STPSkip leaves the resting order untouched and keeps matching against the next one
+	STPSkip SelfTradePrevention = 0
+
+	// STPNone allows the trader to trade with himself
+	STPNone SelfTradePrevention = 1
+
+	// STPCancelNewest cancels the unfilled amount of the taker
+	STPCancelNewest SelfTradePrevention = 2
+
+	// STPCancelOldest cancels the resting order and keeps matching
+	STPCancelOldest SelfTradePrevention = 3
+
+	// STPCancelBoth cancels the resting order and the unfilled amount of the taker
+	STPCancelBoth SelfTradePrevention = 4
+
+	// STPDecrementAndCancel decrements both orders by the smaller amount and cancels the one left with nothing
+	STPDecrementAndCancel SelfTradePrevention = 5
+)
+
+// String implements fmt.Stringer.
+func (s SelfTradePrevention) String() string {
+	switch s {
+	case STPNone:
+		return "none"
+	case STPCancelNewest:
+		return "cancelNewest"
+	case STPCancelOldest:
+		return "cancelOldest"
+	case STPCancelBoth:
+		return "cancelBoth"
+	case STPDecrementAndCancel:
+		return "decrementAndCancel"
+	default:
+		return "skip"
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SelfTradePrevention) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SelfTradePrevention) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"skip"`:
+		*s = STPSkip
+	case `"none"`:
+		*s = STPNone
+	case `"cancelNewest"`:
+		*s = STPCancelNewest
+	case `"cancelOldest"`:
+		*s = STPCancelOldest
+	case `"cancelBoth"`:
+		*s = STPCancelBoth
+	case `"decrementAndCancel"`:
+		*s = STPDecrementAndCancel
+	default:
+		return &json.UnsupportedValueError{
+			Value: reflect.New(reflect.TypeOf(data)),
+			Str:   string(data),
+		}
+	}
+
+	return nil
+}
+
+func (s SelfTradePrevention) valid() bool {
+	return s >= STPSkip && s <= STPDecrementAndCancel
+}
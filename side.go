@@ -28,6 +28,15 @@ func (s Side) String() string {
 	return "sell"
 }
 
+// Opposite returns the other side.
+func (s Side) Opposite() Side {
+	if s == Buy {
+		return Sell
+	}
+
+	return Buy
+}
+
 // MarshalJSON implements json.Marshaler.
 func (s Side) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + s.String() + `"`), nil
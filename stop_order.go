@@ -0,0 +1,76 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ json.Marshaler = (*StopOrder)(nil)
+var _ json.Unmarshaler = (*StopOrder)(nil)
+
+// StopOrder represents an order waiting for the stop price to be reached.
+type StopOrder struct {
+	order     *Order
+	stopPrice decimal.Decimal
+	limit     bool
+}
+
+// NewStopOrder creates a new stop order. When limit is true the order is fired as a limit order, otherwise as a market order.
+func NewStopOrder(order *Order, stopPrice decimal.Decimal, limit bool) *StopOrder {
+	return &StopOrder{order, stopPrice, limit}
+}
+
+// Order returns the order fired when the stop price is reached.
+func (s *StopOrder) Order() *Order {
+	return s.order
+}
+
+// StopPrice returns the stop price.
+func (s *StopOrder) StopPrice() decimal.Decimal {
+	return s.stopPrice
+}
+
+// IsLimit returns true when the order is fired as a limit order.
+func (s *StopOrder) IsLimit() bool {
+	return s.limit
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *StopOrder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		&struct {
+			Order     *Order          `json:"order"`
+			StopPrice decimal.Decimal `json:"stopPrice"`
+			Limit     bool            `json:"limit"`
+		}{
+			s.order,
+			s.stopPrice,
+			s.limit,
+		},
+	)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StopOrder) UnmarshalJSON(data []byte) error {
+	obj := struct {
+		Order     *Order          `json:"order"`
+		StopPrice decimal.Decimal `json:"stopPrice"`
+		Limit     bool            `json:"limit"`
+	}{}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("StopOrder.Unmarshal(%s): %w", data, err)
+	}
+
+	if obj.Order == nil {
+		return fmt.Errorf("StopOrder.Unmarshal(%s): missing order", data)
+	}
+
+	s.order = obj.Order
+	s.stopPrice = obj.StopPrice
+	s.limit = obj.Limit
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package orderbook
+
+import (
+	"container/list"
+
+	"github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/shopspring/decimal"
+)
+
+// StopSide represents all the stop orders of one side waiting to be triggered, keyed by stop price.
+type StopSide struct {
+	side Side
+	tree *redblacktree.Tree
+	size int
+}
+
+// NewStopSide creates a new stop side.
+func NewStopSide(side Side) *StopSide {
+	tree := redblacktree.NewWith(func(a, b interface{}) int {
+		return a.(decimal.Decimal).Cmp(b.(decimal.Decimal))
+	})
+
+	return &StopSide{side, tree, 0}
+}
+
+// Len returns the number of stop orders.
+func (ss *StopSide) Len() int {
+	return ss.size
+}
+
+// Append appends a stop order.
+func (ss *StopSide) Append(order *StopOrder) *list.Element {
+	var orders *list.List
+
+	if value, found := ss.tree.Get(order.stopPrice); found {
+		orders = value.(*list.List)
+	} else {
+		orders = list.New()
+		ss.tree.Put(order.stopPrice, orders)
+	}
+
+	ss.size++
+	return orders.PushBack(order)
+}
+
+// Remove removes a stop order.
+func (ss *StopSide) Remove(e *list.Element) *StopOrder {
+	order := e.Value.(*StopOrder)
+
+	value, found := ss.tree.Get(order.stopPrice)
+	if !found {
+		return order
+	}
+
+	orders := value.(*list.List)
+	orders.Remove(e)
+
+	if orders.Len() == 0 {
+		ss.tree.Remove(order.stopPrice)
+	}
+
+	ss.size--
+	return order
+}
+
+// Triggered returns the oldest stop order triggered by the given price or nil.
+// Buy stops are triggered when the price rises to the stop price, sell stops when it falls to it.
+func (ss *StopSide) Triggered(price decimal.Decimal) *list.Element {
+	if ss.size <= 0 {
+		return nil
+	}
+
+	if ss.side == Buy {
+		node := ss.tree.Left()
+		if node != nil && node.Key.(decimal.Decimal).LessThanOrEqual(price) {
+			return node.Value.(*list.List).Front()
+		}
+	} else {
+		node := ss.tree.Right()
+		if node != nil && node.Key.(decimal.Decimal).GreaterThanOrEqual(price) {
+			return node.Value.(*list.List).Front()
+		}
+	}
+
+	return nil
+}
+
+// Orders return all the stop orders in trigger order. Asc when side is buy. Desc when side is sell.
+func (ss *StopSide) Orders() []*StopOrder {
+	orders := make([]*StopOrder, 0, ss.size)
+
+	it := ss.tree.Iterator()
+	if ss.side == Buy {
+		for it.Next() {
+			for e := it.Value().(*list.List).Front(); e != nil; e = e.Next() {
+				orders = append(orders, e.Value.(*StopOrder))
+			}
+		}
+	} else {
+		for it.End(); it.Prev(); {
+			for e := it.Value().(*list.List).Front(); e != nil; e = e.Next() {
+				orders = append(orders, e.Value.(*StopOrder))
+			}
+		}
+	}
+
+	return orders
+}
@@ -0,0 +1,66 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var _ json.Marshaler = (*TimeInForce)(nil)
+var _ json.Unmarshaler = (*TimeInForce)(nil)
+
+// A TimeInForce tells how long a limit order remains active.
+type TimeInForce int
+
+const (
+	// GTC (Good-Till-Cancel) rests the unfilled amount until it is cancelled
+	GTC TimeInForce = 0
+
+	// IOC (Immediate-Or-Cancel) drops the unfilled amount
+	IOC TimeInForce = 1
+
+	// FOK (Fill-Or-Kill) fills the whole amount or nothing at all
+	FOK TimeInForce = 2
+
+	// GTD (Good-Till-Date) rests the unfilled amount until the expire time
+	GTD TimeInForce = 3
+)
+
+// String implements fmt.Stringer.
+func (t TimeInForce) String() string {
+	switch t {
+	case IOC:
+		return "ioc"
+	case FOK:
+		return "fok"
+	case GTD:
+		return "gtd"
+	default:
+		return "gtc"
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TimeInForce) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TimeInForce) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"gtc"`:
+		*t = GTC
+	case `"ioc"`:
+		*t = IOC
+	case `"fok"`:
+		*t = FOK
+	case `"gtd"`:
+		*t = GTD
+	default:
+		return &json.UnsupportedValueError{
+			Value: reflect.New(reflect.TypeOf(data)),
+			Str:   string(data),
+		}
+	}
+
+	return nil
+}
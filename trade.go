@@ -3,6 +3,7 @@ package orderbook
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -12,10 +13,35 @@ var _ json.Unmarshaler = (*Trade)(nil)
 
 // Trade represents a match between a maker order and a taker order.
 type Trade struct {
-	takerOrderID string
-	makerOrderID string
-	amount       decimal.Decimal
-	price        decimal.Decimal
+	id            uint64
+	version       uint64
+	time          time.Time
+	takerOrderID  string
+	makerOrderID  string
+	takerTraderID string
+	makerTraderID string
+	side          Side
+	amount        decimal.Decimal
+	price         decimal.Decimal
+
+	makerFee    decimal.Decimal
+	takerFee    decimal.Decimal
+	feeCurrency string
+}
+
+// ID returns the trade ID. Trade IDs increase monotonically within a book.
+func (t *Trade) ID() uint64 {
+	return t.id
+}
+
+// Version returns the book version at which the trade was executed.
+func (t *Trade) Version() uint64 {
+	return t.version
+}
+
+// Time returns the execution time.
+func (t *Trade) Time() time.Time {
+	return t.time
 }
 
 // TakerOrderID returns the taker order id.
@@ -28,6 +54,21 @@ func (t *Trade) MakerOrderID() string {
 	return t.makerOrderID
 }
 
+// TakerTraderID returns the taker trader id.
+func (t *Trade) TakerTraderID() string {
+	return t.takerTraderID
+}
+
+// MakerTraderID returns the maker trader id.
+func (t *Trade) MakerTraderID() string {
+	return t.makerTraderID
+}
+
+// Side returns the aggressor side, the side of the taker.
+func (t *Trade) Side() Side {
+	return t.side
+}
+
 // Amount returns the amount.
 func (t *Trade) Amount() decimal.Decimal {
 	return t.amount
@@ -38,24 +79,63 @@ func (t *Trade) Price() decimal.Decimal {
 	return t.price
 }
 
+// MakerFee returns the fee charged to the maker. A negative fee is a rebate.
+func (t *Trade) MakerFee() decimal.Decimal {
+	return t.makerFee
+}
+
+// TakerFee returns the fee charged to the taker. A negative fee is a rebate.
+func (t *Trade) TakerFee() decimal.Decimal {
+	return t.takerFee
+}
+
+// FeeCurrency returns the currency of the fees or an empty string when the trade is not charged.
+func (t *Trade) FeeCurrency() string {
+	return t.feeCurrency
+}
+
 // NewTrade creates a new trade.
-func NewTrade(takerOrderID, makerOrderID string, amount, price decimal.Decimal) *Trade {
-	return &Trade{takerOrderID, makerOrderID, amount, price}
+func NewTrade(ID, version uint64, time time.Time, takerOrderID, makerOrderID, takerTraderID, makerTraderID string, side Side, amount, price decimal.Decimal) *Trade {
+	return &Trade{ID, version, time, takerOrderID, makerOrderID, takerTraderID, makerTraderID, side, amount, price, decimal.Zero, decimal.Zero, ""}
 }
 
 // MarshalJSON implements json.Marshaler.
 func (t *Trade) MarshalJSON() ([]byte, error) {
+	var makerFee, takerFee *decimal.Decimal
+	if t.feeCurrency != "" {
+		makerFee = &t.makerFee
+		takerFee = &t.takerFee
+	}
+
 	return json.Marshal(
 		&struct {
-			TakerOrderID string          `json:"takerOrderId"`
-			MakerOrderID string          `json:"makerOrderId"`
-			Amount       decimal.Decimal `json:"amount"`
-			Price        decimal.Decimal `json:"price"`
+			ID            uint64           `json:"id"`
+			Version       uint64           `json:"version"`
+			Time          time.Time        `json:"time"`
+			TakerOrderID  string           `json:"takerOrderId"`
+			MakerOrderID  string           `json:"makerOrderId"`
+			TakerTraderID string           `json:"takerTraderId"`
+			MakerTraderID string           `json:"makerTraderId"`
+			Side          Side             `json:"side"`
+			Amount        decimal.Decimal  `json:"amount"`
+			Price         decimal.Decimal  `json:"price"`
+			MakerFee      *decimal.Decimal `json:"makerFee,omitempty"`
+			TakerFee      *decimal.Decimal `json:"takerFee,omitempty"`
+			FeeCurrency   string           `json:"feeCurrency,omitempty"`
 		}{
+			t.id,
+			t.version,
+			t.time,
 			t.takerOrderID,
 			t.makerOrderID,
+			t.takerTraderID,
+			t.makerTraderID,
+			t.side,
 			t.amount,
 			t.price,
+			makerFee,
+			takerFee,
+			t.feeCurrency,
 		},
 	)
 }
@@ -63,20 +143,38 @@ func (t *Trade) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements json.Unmarshaler.
 func (t *Trade) UnmarshalJSON(data []byte) error {
 	obj := struct {
-		TakerOrderID string          `json:"takerOrderId"`
-		MakerOrderID string          `json:"makerOrderId"`
-		Amount       decimal.Decimal `json:"amount"`
-		Price        decimal.Decimal `json:"price"`
+		ID            uint64          `json:"id"`
+		Version       uint64          `json:"version"`
+		Time          time.Time       `json:"time"`
+		TakerOrderID  string          `json:"takerOrderId"`
+		MakerOrderID  string          `json:"makerOrderId"`
+		TakerTraderID string          `json:"takerTraderId"`
+		MakerTraderID string          `json:"makerTraderId"`
+		Side          Side            `json:"side"`
+		Amount        decimal.Decimal `json:"amount"`
+		Price         decimal.Decimal `json:"price"`
+		MakerFee      decimal.Decimal `json:"makerFee"`
+		TakerFee      decimal.Decimal `json:"takerFee"`
+		FeeCurrency   string          `json:"feeCurrency"`
 	}{}
 
 	if err := json.Unmarshal(data, &obj); err != nil {
 		return fmt.Errorf("Trade.Unmarshal(%s): %w", data, err)
 	}
 
+	t.id = obj.ID
+	t.version = obj.Version
+	t.time = obj.Time
 	t.takerOrderID = obj.TakerOrderID
 	t.makerOrderID = obj.MakerOrderID
+	t.takerTraderID = obj.TakerTraderID
+	t.makerTraderID = obj.MakerTraderID
+	t.side = obj.Side
 	t.amount = obj.Amount
 	t.price = obj.Price
+	t.makerFee = obj.MakerFee
+	t.takerFee = obj.TakerFee
+	t.feeCurrency = obj.FeeCurrency
 
 	return nil
 }
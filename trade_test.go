@@ -0,0 +1,56 @@
+package orderbook_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/danielgatis/go-orderbook"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradeIDs(t *testing.T) {
+	book := orderbook.NewOrderBook("BTC/USD", orderbook.WithClock(clock))
+
+	_, err := book.ProcessLimitOrder("1", "maker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	_, err = book.ProcessLimitOrder("2", "maker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+
+	trades, err := book.ProcessLimitOrder("3", "taker", orderbook.Buy, decimal.NewFromInt(2), decimal.NewFromInt(101))
+	assert.Nil(t, err)
+	assert.Len(t, trades, 2)
+
+	for i, trade := range trades {
+		assert.Equal(t, uint64(i+1), trade.ID())
+		assert.Equal(t, book.Version(), trade.Version())
+		assert.Equal(t, clock(), trade.Time())
+		assert.Equal(t, orderbook.Buy, trade.Side())
+		assert.Equal(t, "taker", trade.TakerTraderID())
+		assert.Equal(t, "maker", trade.MakerTraderID())
+	}
+
+	data, err := json.Marshal(book)
+	assert.Nil(t, err)
+
+	restored := orderbook.NewOrderBook("", orderbook.WithClock(clock))
+	assert.Nil(t, json.Unmarshal(data, restored))
+	assert.Equal(t, uint64(2), restored.LastTradeID())
+
+	_, err = restored.ProcessLimitOrder("4", "maker", orderbook.Sell, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+
+	trades, err = restored.ProcessLimitOrder("5", "taker", orderbook.Buy, decimal.NewFromInt(1), decimal.NewFromInt(100))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), trades[0].ID())
+
+	var trade orderbook.Trade
+	data, err = json.Marshal(trades[0])
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &trade))
+
+	restoredData, err := json.Marshal(&trade)
+	assert.Nil(t, err)
+	assert.Equal(t, string(data), string(restoredData))
+}
@@ -0,0 +1,67 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+var _ json.Marshaler = (*TradingStatus)(nil)
+var _ json.Unmarshaler = (*TradingStatus)(nil)
+
+// A TradingStatus tells which calls an order book accepts.
+type TradingStatus int
+
+const (
+	// Open accepts everything
+	Open TradingStatus = 0
+
+	// Halted freezes the market, rejecting even cancels
+	Halted TradingStatus = 1
+
+	// CancelOnly only accepts cancels
+	CancelOnly TradingStatus = 2
+
+	// PostOnlyOnly only accepts post only orders and cancels
+	PostOnlyOnly TradingStatus = 3
+
+	// Auction accumulates limit orders without matching until the auction is uncrossed
+	Auction TradingStatus = 4
+
+	// Closed rejects new orders but accepts cancels
+	Closed TradingStatus = 5
+)
+
+var tradingStatuses = []string{"open", "halted", "cancelOnly", "postOnlyOnly", "auction", "closed"}
+
+// String implements fmt.Stringer.
+func (s TradingStatus) String() string {
+	if s < 0 || int(s) >= len(tradingStatuses) {
+		return "unknown"
+	}
+
+	return tradingStatuses[s]
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TradingStatus) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TradingStatus) UnmarshalJSON(data []byte) error {
+	for i, name := range tradingStatuses {
+		if string(data) == `"`+name+`"` {
+			*s = TradingStatus(i)
+			return nil
+		}
+	}
+
+	return &json.UnsupportedValueError{
+		Value: reflect.New(reflect.TypeOf(data)),
+		Str:   string(data),
+	}
+}
+
+func (s TradingStatus) valid() bool {
+	return s >= Open && s <= Closed
+}